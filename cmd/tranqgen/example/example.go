@@ -0,0 +1,79 @@
+// Package example contains tagged struct types and the
+// serializer generated for them by tranqgen, testing the
+// generated serializer against serializers.Base.
+package example
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/chuckpreslar/tranq/cmd/tranqgen -type Post,Comment,Person,Tag,Summary
+
+// Person is linked as the author of
+// posts and comments.
+type Person struct {
+	ID       string `tranq_self:"/people/{id}"`
+	Name     string `json:"name"`
+	Email    string `tranq:"email,omitempty"`
+	Password string `tranq:"-"`
+	session  string `tranq_ignore:"true"`
+}
+
+// Post links resources of each supported form.
+type Post struct {
+	ID       int `tranq_self:"/posts"`
+	Title    string
+	Body     string  `tranq:"body,omitempty"`
+	Rating   float64 `tranq:",omitempty"`
+	Draft    bool
+	Created  time.Time
+	Labels   map[string]string `tranq:",omitempty"`
+	Author   *Person           `tranq_link:"true" tranq_href:"/people"`
+	Comments []Comment         `tranq_link:"true" tranq_href:"/posts/{owner.id}/comments"`
+	Tags     [2]*Tag           `tranq_link:"true"`
+	Revision uint              `tranq_meta:"true" tranq:",omitempty"`
+}
+
+// TranqMeta implements the serializers.MetaProvider
+// interface for the Post type.
+func (p *Post) TranqMeta() map[string]interface{} {
+	return map[string]interface{}{"draft": p.Draft}
+}
+
+// Comment links back to its post
+// and to its replies.
+type Comment struct {
+	ID      int64
+	Body    string
+	Author  Person     `tranq_link:"true" tranq_href:"/people"`
+	Post    *Post      `tranq_link:"true"`
+	Replies []*Comment `tranq_link:"true" tranq_href:"/comments"`
+}
+
+// ViewerKey is the context.Context key of the
+// identifier of the person viewing comments.
+type ViewerKey struct{}
+
+// TranqMetaContext implements the serializers.ContextMetaProvider
+// interface for the Comment type, marking comments written by
+// the person viewing them.
+func (c Comment) TranqMetaContext(ctx context.Context) map[string]interface{} {
+	if id, ok := ctx.Value(ViewerKey{}).(string); ok && id == c.Author.ID {
+		return map[string]interface{}{"own": true}
+	}
+
+	return nil
+}
+
+// Tag is linked by reference or in full.
+type Tag struct {
+	ID   uint8
+	Name string `tranq:"name,omitempty"`
+}
+
+// Summary has no identifier.
+type Summary struct {
+	Title string
+	Posts []Post `tranq_link:"true" tranq_href:"/posts"`
+}
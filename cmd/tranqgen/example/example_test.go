@@ -0,0 +1,241 @@
+package example_test
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/cmd/tranqgen/example"
+	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/chuckpreslar/tranq/tranqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSerializers returns the generated serializer
+// and the reflective serializer created by the
+// configurator `c`.
+func newSerializers(c *configurators.Base) (serializers.Serializer, serializers.Serializer) {
+	var generated = example.NewTranqSerializer(c.NewSerializer().(*serializers.Base))
+
+	return generated, c.NewSerializer()
+}
+
+func fixtures() []interface{} {
+	var (
+		author = example.Person{ID: "ada", Name: "Ada", Email: "ada@example.com", Password: "secret"}
+		tag    = &example.Tag{ID: 1, Name: "go"}
+		post   = example.Post{
+			ID:       1,
+			Title:    "Generated",
+			Body:     "Without reflection.",
+			Rating:   4.5,
+			Created:  time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC),
+			Labels:   map[string]string{"Lang": "go"},
+			Author:   &author,
+			Tags:     [2]*example.Tag{tag, {ID: 2}},
+			Revision: 3,
+		}
+		draft = example.Post{ID: 2, Draft: true, Title: "Draft"}
+	)
+
+	post.Comments = []example.Comment{
+		{ID: 10, Body: "First", Author: author, Post: &draft},
+		{ID: 11, Body: "Second", Author: example.Person{ID: "bob"}},
+	}
+	post.Comments[0].Replies = []*example.Comment{&post.Comments[1], nil}
+
+	return []interface{}{
+		post,
+		&post,
+		(*example.Post)(nil),
+		draft,
+		[]example.Post{post, draft},
+		[]*example.Post{&post, nil, &draft},
+		[]example.Post{},
+		post.Comments,
+		author,
+		*tag,
+		[]*example.Tag{tag},
+		example.Summary{Title: "Posts", Posts: []example.Post{post, draft}},
+		&example.Summary{},
+		[]example.Summary{{Title: "Empty"}},
+		map[string]interface{}{"post": post.Title},
+		[]int{1, 2, 3},
+	}
+}
+
+func configurations() map[string]configurators.Settings {
+	return map[string]configurators.Settings{
+		"default": {},
+		"formatters": {
+			TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
+				return strings.ToLower(s) + "s"
+			}),
+			AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+			HrefFormatter: serializers.HrefFormatterFunc(func(h, o, c string, i []interface{}) string {
+				return "/api" + h
+			}),
+			FormatMapKeys: true,
+		},
+		"templates": {
+			HrefFormatter: serializers.TemplateHrefFormatter{},
+			LinkTemplates: true,
+			SelfTemplates: map[string]string{"Comment": "/comments/{id}", "Post": "/articles/{id}"},
+		},
+		"conflicts": {ConflictPolicy: serializers.ConflictMerge},
+		"strict":    {ConflictPolicy: serializers.ConflictFail},
+		"depth":     {MaxDepth: 1},
+		"json":      {UseJSONTags: true},
+		"identifier": {
+			IdentifierStrategy: serializers.FieldIdentifier("ID"),
+		},
+	}
+}
+
+func options() map[string][]serializers.Option {
+	var u, _ = url.Parse("http://example.com/posts?page[number]=2&page[size]=2")
+
+	return map[string][]serializers.Option{
+		"none":    nil,
+		"include": {serializers.Include("author", "comments.replies")},
+		"empty":   {serializers.Include("")},
+		"fields":  {serializers.Fields("Post", "Title", "Author"), serializers.Fields("Person", "Name")},
+		"strict":  {serializers.Fields("Post", "Title", "Unknown"), serializers.StrictFields()},
+		"meta":    {serializers.Meta(map[string]interface{}{"version": 1})},
+		"paginate": {
+			serializers.Paginate(serializers.PageNumberPaginator{}, u, serializers.PageResult{Number: 2, Size: 2, Total: 5, HasTotal: true}),
+		},
+	}
+}
+
+func TestTranqSerializerEquivalence(t *testing.T) {
+	for name, config := range configurations() {
+		for option, o := range options() {
+			for _, fixture := range fixtures() {
+				var generated, reference = newSerializers(configurators.NewBase(config))
+
+				if !tranqtest.AssertEquivalent(t, generated, reference, fixture, o...) {
+					t.Logf("configuration %s, options %s", name, option)
+				}
+			}
+		}
+	}
+}
+
+func TestTranqSerializerContext(t *testing.T) {
+	var (
+		generated, reference = newSerializers(new(configurators.Base))
+		ctx, cancel          = context.WithCancel(context.WithValue(context.Background(), example.ViewerKey{}, "ada"))
+	)
+
+	for _, fixture := range fixtures() {
+		tranqtest.AssertEquivalentContext(t, ctx, generated, reference, fixture, serializers.Include("Comments"))
+	}
+
+	var result, err = generated.AcceptContext(ctx, fixtures()[0], serializers.Include("Comments"))
+
+	if assert.Nil(t, err) {
+		var comments = result["linked"].(map[string]interface{})["Comment"].([]interface{})
+		assert.Equal(t, map[string]interface{}{"own": true}, comments[0].(map[string]interface{})["meta"], "failed to pass context to TranqMetaContext")
+	}
+
+	cancel()
+
+	_, err = generated.AcceptContext(ctx, fixtures()[0])
+
+	assert.Equal(t, context.Canceled, err, "failed to return error of done context")
+
+	for _, fixture := range fixtures() {
+		tranqtest.AssertEquivalentContext(t, ctx, generated, reference, fixture)
+	}
+}
+
+func TestTranqSerializerReuse(t *testing.T) {
+	var (
+		config               = new(configurators.Base)
+		generated, reference = newSerializers(config)
+	)
+
+	for i := 0; i < 2; i++ {
+		for _, fixture := range fixtures() {
+			tranqtest.AssertEquivalent(t, generated, reference, fixture, serializers.Include("comments"))
+		}
+	}
+}
+
+func TestTranqSerializerConcurrent(t *testing.T) {
+	var (
+		generated, reference = newSerializers(new(configurators.Base))
+		group                sync.WaitGroup
+	)
+
+	for i := 0; i < 8; i++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			for _, fixture := range fixtures() {
+				tranqtest.AssertEquivalent(t, generated, reference, fixture, serializers.Include("author", "comments"))
+			}
+		}()
+	}
+
+	group.Wait()
+}
+
+func TestTranqSerializerWithoutReflection(t *testing.T) {
+	var (
+		cache     = new(serializers.TypeCache)
+		base      = &serializers.Base{TypeCache: cache}
+		generated = example.NewTranqSerializer(base)
+		post      = fixtures()[0]
+	)
+
+	var _, err = generated.Accept(post)
+
+	assert.Nil(t, err)
+
+	var _, ok = cache.Load(reflect.TypeOf(post))
+
+	assert.False(t, ok, "generated serializer read the metadata of a generated type")
+
+	_, err = base.Accept(post)
+	assert.Nil(t, err)
+
+	_, ok = cache.Load(reflect.TypeOf(post))
+	assert.True(t, ok, "reflective serializer failed to cache the metadata of a type")
+}
+
+func BenchmarkTranqSerializer(b *testing.B) {
+	var (
+		generated, _ = newSerializers(new(configurators.Base))
+		post         = fixtures()[0]
+	)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		generated.Accept(post)
+	}
+}
+
+func BenchmarkBaseSerializer(b *testing.B) {
+	var (
+		_, reference = newSerializers(new(configurators.Base))
+		post         = fixtures()[0]
+	)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		reference.Accept(post)
+	}
+}
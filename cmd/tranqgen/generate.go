@@ -0,0 +1,482 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// serializersPath is the import path of
+	// the serializers package.
+	serializersPath = "github.com/chuckpreslar/tranq/serializers"
+)
+
+// Generator writes the source of a serializer
+// for the resources of a Package.
+type Generator struct {
+	// Serializer is the name of the generated type.
+	Serializer string
+	// Command is the command line recorded
+	// in the generated file's header.
+	Command string
+	// buffer contains the body of the generated file.
+	buffer bytes.Buffer
+	// imports contains the standard library packages
+	// the generated file uses.
+	imports map[string]bool
+}
+
+// Generate returns the formatted source of a serializer for
+// the resources of the Package `p`.
+func (g *Generator) Generate(p *Package) ([]byte, error) {
+	g.buffer.Reset()
+	g.imports = make(map[string]bool)
+
+	g.generateSerializer(p)
+
+	for _, resource := range p.Resources {
+		g.generateResource(resource)
+	}
+
+	var (
+		source  bytes.Buffer
+		imports = make([]string, 0, len(g.imports))
+	)
+
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+
+	sort.Strings(imports)
+
+	fmt.Fprintf(&source, "// Code generated by \"%s\"; DO NOT EDIT.\n\n", g.Command)
+	fmt.Fprintf(&source, "package %s\n\n", p.Name)
+
+	if 0 < len(imports) {
+		source.WriteString("import (\n")
+
+		for _, path := range imports {
+			fmt.Fprintf(&source, "\t%q\n", path)
+		}
+
+		source.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&source, "import (\n\t%q\n)\n", serializersPath)
+	source.Write(g.buffer.Bytes())
+
+	return format.Source(source.Bytes())
+}
+
+// comment writes the formatted text as a line
+// comment, wrapped at the width of a doc comment.
+func (g *Generator) comment(f string, args ...interface{}) {
+	var (
+		words = strings.Fields(fmt.Sprintf(f, args...))
+		line  = "//"
+	)
+
+	g.printf("\n")
+
+	for _, word := range words {
+		if 2 < len(line) && 64 < len(line)+1+len(word) {
+			g.printf("%s\n", line)
+			line = "//"
+		}
+
+		line += " " + word
+	}
+
+	g.printf("%s\n", line)
+}
+
+// printf writes formatted source to
+// the body of the generated file.
+func (g *Generator) printf(f string, args ...interface{}) {
+	fmt.Fprintf(&g.buffer, f, args...)
+}
+
+// generateSerializer writes the serializer type, its
+// constructor and its Accept method.
+func (g *Generator) generateSerializer(p *Package) {
+	g.imports["context"] = true
+
+	var names = make([]string, 0, len(p.Resources))
+
+	for _, resource := range p.Resources {
+		names = append(names, resource.Name)
+	}
+
+	var list = names[len(names)-1]
+
+	if 1 < len(names) {
+		list = strings.Join(names[:len(names)-1], ", ") + " and " + list
+	}
+
+	g.comment("%s is a serializers.Serializer serializing the types %s without reflection, producing the same documents as the serializers.Base it embeds.", g.Serializer, list)
+	g.printf("type %s struct {\n*serializers.Base\n}\n\n", g.Serializer)
+
+	g.printf("// New%s returns a %s\n// serializing with the settings of `b`.\n", g.Serializer, g.Serializer)
+	g.printf("func New%s(b *serializers.Base) *%s {\nreturn &%s{b}\n}\n\n", g.Serializer, g.Serializer, g.Serializer)
+
+	g.printf("// Accept implements the serializers.Serializer interface.\n")
+	g.printf("func (s *%s) Accept(i interface{}, options ...serializers.Option) (map[string]interface{}, error) {\n", g.Serializer)
+	g.printf("return s.AcceptContext(context.Background(), i, options...)\n}\n\n")
+
+	g.printf("// AcceptContext implements the serializers.Serializer interface,\n")
+	g.printf("// serializing with a Fork of the embedded serializers.Base carrying\n")
+	g.printf("// `ctx` and deferring to it for values of other types and settings\n")
+	g.printf("// the generated code does not support.\n")
+	g.printf("func (s *%s) AcceptContext(ctx context.Context, i interface{}, options ...serializers.Option) (map[string]interface{}, error) {\n", g.Serializer)
+
+	var fallback = "nil != s.IdentifierStrategy || serializers.FieldIdentifier(serializers.ID) != serializers.DefaultIdentifierStrategy"
+
+	if p.TagSensitive && p.JSONTags {
+		fallback += " || !s.UseJSONTags"
+	} else if p.TagSensitive {
+		fallback += " || s.UseJSONTags"
+	}
+
+	g.printf("if %s {\nreturn s.Base.AcceptContext(ctx, i, options...)\n}\n\n", fallback)
+	g.printf("var fork = &%s{s.ForkContext(ctx)}\n\n", g.Serializer)
+	g.printf("switch value := i.(type) {\n")
+
+	for _, resource := range p.Resources {
+		var (
+			name      = strconv.Quote(resource.Name)
+			serialize = "fork.serialize" + resource.Name
+		)
+
+		g.printf("case %s:\n", resource.Name)
+		g.printf("return fork.AcceptDocument(s.FormatTypeName(%s), func() (interface{}, error) {\nreturn %s(value)\n}, options...)\n", name, serialize)
+
+		g.printf("case *%s:\n", resource.Name)
+		g.printf("return fork.AcceptDocument(s.FormatTypeName(%s), func() (interface{}, error) {\n", name)
+		g.printf("if nil == value {\nreturn nil, nil\n}\n\nreturn %s(*value)\n}, options...)\n", serialize)
+
+		for _, pointer := range []bool{false, true} {
+			var element = "value[j]"
+
+			if pointer {
+				g.printf("case []*%s:\n", resource.Name)
+			} else {
+				g.printf("case []%s:\n", resource.Name)
+			}
+
+			g.printf("return fork.AcceptDocument(s.FormatTypeName(%s), func() (interface{}, error) {\n", name)
+
+			if nil != resource.ID {
+				g.printf("var typ = s.FormatTypeName(%s)\n\n", name)
+				g.printf("for j := 0; j < len(value); j++ {\n")
+
+				if pointer {
+					g.printf("if nil != value[j] {\nfork.MarkDocumentKey([2]string{typ, %s})\n}\n}\n\n", g.key(resource.ID, "value[j].ID"))
+				} else {
+					g.printf("fork.MarkDocumentKey([2]string{typ, %s})\n}\n\n", g.key(resource.ID, "value[j].ID"))
+				}
+			}
+
+			g.printf("var collection = make([]interface{}, 0, len(value))\n\n")
+			g.printf("for j := 0; j < len(value); j++ {\n")
+
+			if pointer {
+				g.printf("if nil == value[j] {\ncollection = append(collection, nil)\ncontinue\n}\n\n")
+				element = "*value[j]"
+			}
+
+			g.printf("var document, err = %s(%s)\n\n", serialize, element)
+			g.printf("if nil != err {\nreturn nil, err\n}\n\n")
+			g.printf("collection = append(collection, document)\n}\n\n")
+			g.printf("return collection, nil\n}, options...)\n")
+		}
+	}
+
+	g.printf("}\n\nreturn s.Base.AcceptContext(ctx, i, options...)\n}\n")
+}
+
+// generateResource writes the methods
+// serializing the Resource `r`.
+func (g *Generator) generateResource(r *Resource) {
+	g.printf("\n// serialize%s serializes the %s `v` as serializers.Base would.\n", r.Name, r.Name)
+	g.printf("func (s *%s) serialize%s(v %s) (interface{}, error) {\n", g.Serializer, r.Name, r.Name)
+	g.printf("if err := s.Err(); nil != err {\nreturn nil, err\n}\n\n")
+	g.printf("var (\nmapping = make(map[string]interface{})\ntyp = s.FormatTypeName(%q)\nerr error\n)\n\n", r.Name)
+
+	if nil != r.ID {
+		g.printf("if err = s.EnterDocumentKey([2]string{typ, %s}); nil != err {\nreturn nil, err\n}\n\n", g.key(r.ID, "v.ID"))
+		g.printf("defer s.LeaveDocument()\n\n")
+		g.printf("var id = s.ReservedStrings.ID\n\nif 0 == len(id) {\nid = %s\n}\n\n", g.attribute(r.ID))
+		g.assign("mapping[id]", r.ID, "v.ID")
+	}
+
+	var attributes = make([]string, 0, len(r.Fields))
+
+	for _, field := range r.Fields {
+		attributes = append(attributes, g.attribute(field))
+	}
+
+	if 0 < len(r.Fields) {
+		g.printf("var attributes = [...]string{%s}\n\n", strings.Join(attributes, ", "))
+	}
+
+	g.printf("var fieldset, sparse = s.Fieldsets[typ]\n\n")
+	g.printf("if sparse && s.StrictFields {\n")
+	g.printf("var names = make(map[string]struct{}, %d)\n\n", len(r.Fields))
+
+	if 0 < len(r.Fields) {
+		g.printf("for j := 0; j < len(attributes); j++ {\nnames[attributes[j]] = struct{}{}\n}\n\n")
+	}
+
+	g.printf("if err = serializers.CheckFieldset(typ, fieldset, names); nil != err {\nreturn nil, err\n}\n}\n\n")
+
+	for i, field := range r.Fields {
+		var (
+			value = "v." + field.Name
+			attr  = fmt.Sprintf("attributes[%d]", i)
+		)
+
+		g.printf("if _, ok := fieldset[%s]; !sparse || ok {\n", attr)
+
+		if field.Tag.OmitEmpty {
+			g.printf("if %s {\n", g.present(field, value))
+		}
+
+		if nil != field.Link {
+			g.printf("if err = s.link%s%s(mapping, v, %s); nil != err {\nreturn nil, err\n}\n", r.Name, field.Name, attr)
+		} else if 0 < len(field.Basic) {
+			g.printf("mapping[%s] = %s\n", attr, value)
+		} else {
+			g.printf("if mapping[%s], err = s.SerializeAttribute(v, %s); nil != err {\nreturn nil, err\n}\n", attr, value)
+		}
+
+		if field.Tag.OmitEmpty {
+			g.printf("}\n")
+		}
+
+		g.printf("}\n\n")
+	}
+
+	if r.HasSelf && nil != r.ID {
+		g.printf("var template, ok = s.SelfTemplates[typ]\n\nif !ok {\ntemplate = %q\n}\n\n", r.Self)
+		g.printf("s.Links(mapping)[s.ReservedStrings.Self] = serializers.ExpandSelfTemplate(template, v.ID)\n\n")
+	} else if nil != r.ID {
+		g.printf("if template, ok := s.SelfTemplates[typ]; ok {\n")
+		g.printf("s.Links(mapping)[s.ReservedStrings.Self] = serializers.ExpandSelfTemplate(template, v.ID)\n}\n\n")
+	}
+
+	if 0 < len(r.Meta) || r.MetaProvider || r.ContextMetaProvider {
+		g.printf("var meta = make(map[string]interface{})\n\n")
+
+		for _, field := range r.Meta {
+			var value = "v." + field.Name
+
+			if field.Tag.OmitEmpty {
+				g.printf("if %s {\n", g.present(field, value))
+			}
+
+			if 0 < len(field.Basic) {
+				g.printf("serializers.MergeMeta(meta, %s, %s)\n", g.attribute(field), value)
+			} else {
+				g.printf("if value, err := s.Serialize(%s); nil != err {\nreturn nil, err\n} else {\n", value)
+				g.printf("serializers.MergeMeta(meta, %s, value)\n}\n", g.attribute(field))
+			}
+
+			if field.Tag.OmitEmpty {
+				g.printf("}\n")
+			}
+
+			g.printf("\n")
+		}
+
+		if r.ContextMetaProvider {
+			g.printf("for key, value := range v.TranqMetaContext(s.Context()) {\nmeta[key] = value\n}\n\n")
+		} else if r.MetaProvider {
+			g.printf("for key, value := range v.TranqMeta() {\nmeta[key] = value\n}\n\n")
+		}
+
+		g.printf("if 0 < len(meta) {\nmapping[s.ReservedStrings.Meta] = meta\n}\n\n")
+	}
+
+	g.printf("return mapping, nil\n}\n")
+
+	for _, field := range r.Fields {
+		if nil != field.Link {
+			g.generateLink(r, field)
+		}
+	}
+
+	if r.Linked {
+		g.generateLinked(r)
+	}
+}
+
+// generateLink writes the method linking the
+// resources of the field `f` of the Resource `r`.
+func (g *Generator) generateLink(r *Resource, f *Field) {
+	var (
+		link  = f.Link
+		value = "v." + f.Name
+	)
+
+	g.printf("\n// link%s%s links the %s of the %s `v` under the\n", r.Name, f.Name, f.Name, r.Name)
+	g.printf("// attribute name `attr`, as serializers.Base would.\n")
+	g.printf("func (s *%s) link%s%s(m map[string]interface{}, v %s, attr string) error {\n", g.Serializer, r.Name, f.Name, r.Name)
+	g.printf("var links = s.Links(m)\n\n")
+
+	if link.Pointer && !link.Many {
+		g.printf("if nil == %s {\nlinks[attr] = nil\nreturn nil\n}\n\n", value)
+	}
+
+	g.printf("var (\ndetails = make(map[string]interface{})\ntyp = s.FormatTypeName(%q)\n", link.Resource.Name)
+	g.printf("ids = make([]interface{}, 0, 0)\ninclude, included = s.IncludePaths.Relationship(attr)\nparent = s.IncludePaths\n)\n\n")
+	g.printf("s.IncludePaths = include\ndefer func() { s.IncludePaths = parent }()\n\n")
+
+	var resource = value
+
+	if link.Many {
+		g.printf("for j := 0; j < len(%s); j++ {\n", value)
+		resource = value + "[j]"
+
+		if link.Pointer {
+			g.printf("if nil == %s {\ncontinue\n}\n\n", resource)
+		}
+	}
+
+	if link.Pointer {
+		resource = "*" + resource
+	}
+
+	g.printf("var resource = %s\n\n", resource)
+	g.printf("ids = append(ids, resource.ID)\n\n")
+
+	if !link.Many {
+		g.printf("details[s.ReservedStrings.ID] = resource.ID\n\n")
+	}
+
+	g.printf("if included && s.compound%s(resource) {\n", link.Resource.Name)
+	g.printf("if err := s.link%s(typ, resource); nil != err {\nreturn err\n}\n}\n", link.Resource.Name)
+
+	if link.Many {
+		g.printf("}\n\ndetails[s.ReservedStrings.IDs] = ids\n")
+	}
+
+	g.printf("\n")
+
+	if 0 < len(f.Href) {
+		var id = "nil"
+
+		if nil != r.ID {
+			id = "v.ID"
+		}
+
+		g.printf("var owner = s.FormatTypeName(%q)\n\n", r.Name)
+		g.printf("details[s.ReservedStrings.Href] = s.FormatOwnerHref(%q, owner, %s, typ, ids)\n\n", f.Href, id)
+		g.printf("if s.LinkTemplates {\ns.LinkTemplate(owner, attr, typ, %q)\n}\n\n", f.Href)
+	}
+
+	g.printf("details[s.ReservedStrings.Type] = typ\nlinks[attr] = details\n\nreturn nil\n}\n")
+}
+
+// generateLinked writes the methods sideloading
+// linked values of the Resource `r`.
+func (g *Generator) generateLinked(r *Resource) {
+	g.printf("\n// compound%s reports whether the %s `v` carries more than\n", r.Name, r.Name)
+	g.printf("// its identifier, as serializers.Base's IsCompoundDocument.\n")
+	g.printf("func (s *%s) compound%s(v %s) bool {\n", g.Serializer, r.Name, r.Name)
+
+	for _, fields := range [][]*Field{r.Fields, r.Meta} {
+		for _, field := range fields {
+			g.printf("if %s {\nreturn true\n}\n\n", g.present(field, "v."+field.Name))
+		}
+	}
+
+	g.printf("return false\n}\n")
+
+	g.printf("\n// link%s sideloads the %s `v` as a linked\n", r.Name, r.Name)
+	g.printf("// document of the formatted type name `typ`.\n")
+	g.printf("func (s *%s) link%s(typ string, v %s) error {\n", g.Serializer, r.Name, r.Name)
+	g.printf("return s.LinkDocument([2]string{typ, %s}, typ, func() (interface{}, error) {\n", g.key(r.ID, "v.ID"))
+	g.printf("return s.serialize%s(v)\n})\n}\n", r.Name)
+}
+
+// assign writes the assignment of the
+// serialized value `v` of the field `f`
+// to the expression `e`.
+func (g *Generator) assign(e string, f *Field, v string) {
+	if 0 < len(f.Basic) {
+		g.printf("%s = %s\n\n", e, v)
+		return
+	}
+
+	g.printf("if %s, err = s.Serialize(%s); nil != err {\nreturn nil, err\n}\n\n", e, v)
+}
+
+// attribute returns an expression evaluating
+// to the attribute name of the field `f`.
+func (g *Generator) attribute(f *Field) string {
+	if 0 < len(f.Tag.Name) {
+		return strconv.Quote(f.Tag.Name)
+	}
+
+	return fmt.Sprintf("s.FormatAttributeName(%q)", f.Name)
+}
+
+// key returns an expression formatting the identifier `v`
+// of the field `f` as serializers.Base's DocumentKey.
+func (g *Generator) key(f *Field, v string) string {
+	switch f.Basic {
+	case "string":
+		return v
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		g.imports["strconv"] = true
+		return fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", v)
+	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		g.imports["strconv"] = true
+		return fmt.Sprintf("strconv.FormatUint(uint64(%s), 10)", v)
+	case "bool":
+		g.imports["strconv"] = true
+		return fmt.Sprintf("strconv.FormatBool(%s)", v)
+	}
+
+	g.imports["fmt"] = true
+
+	return fmt.Sprintf("fmt.Sprint(%s)", v)
+}
+
+// present returns an expression reporting whether the value
+// `v` of the field `f` is not the zero value for its type, as
+// serializers.Base's IsZeroValue, or not empty as defined by
+// serializers.IsEmptyJSONValue if its Tag was parsed from
+// the `json` struct tag.
+func (g *Generator) present(f *Field, v string) string {
+	if f.Tag.JSON {
+		switch f.Basic {
+		case "":
+			return fmt.Sprintf("!serializers.IsEmptyJSONValue(%s)", v)
+		case "bool":
+			return v
+		case "string":
+			return fmt.Sprintf("\"\" != %s", v)
+		}
+
+		return "0 != " + v
+	}
+
+	switch f.Basic {
+	case "":
+		return fmt.Sprintf("!s.IsZero(%s)", v)
+	case "bool":
+		return v
+	case "string":
+		return fmt.Sprintf("\"\" != %s", v)
+	case "float32", "float64":
+		g.imports["math"] = true
+		return fmt.Sprintf("0 != math.Float64bits(float64(%s))", v)
+	}
+
+	return "0 != " + v
+}
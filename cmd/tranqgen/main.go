@@ -0,0 +1,94 @@
+// Tranqgen generates serializers for tagged struct types that
+// produce the same documents as serializers.Base without the
+// use of reflection. Given the names of struct types declared
+// in the package of the current directory, i.e.
+//
+//	//go:generate tranqgen -type Post,Comment,Person
+//
+// it writes the file post_tranq.go declaring the type
+// TranqSerializer, a serializers.Serializer wrapping a
+// *serializers.Base. Fields are read as serializers.Base
+// reads them: identifiers from the field named ID, links from
+// fields tagged `tranq_link:"true"` with the `tranq_href`
+// struct tag, and names and options from the `tranq` struct
+// tag. Linked fields must hold one of the types passed to
+// -type, a pointer to one or a slice or array of either.
+//
+// Values of other types, and serializers configured with an
+// IdentifierStrategy, are serialized by the wrapped
+// *serializers.Base. Attribute values other than booleans,
+// strings and numbers are also serialized by it, one value
+// at a time.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames  = flag.String("type", "", "comma-separated list of struct type names; required")
+	serializer = flag.String("serializer", "TranqSerializer", "name of the generated serializer type")
+	output     = flag.String("output", "", "output file name; default <type>_tranq.go")
+	jsonTags   = flag.Bool("json", false, "read the json struct tag of fields without a tranq struct tag, as UseJSONTags")
+)
+
+// usage writes the command line usage
+// of tranqgen to standard error.
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of tranqgen:\n")
+	fmt.Fprintf(os.Stderr, "\ttranqgen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("tranqgen: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if 0 == len(*typeNames) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var (
+		types = strings.Split(*typeNames, ",")
+		dir   = "."
+	)
+
+	if 0 < flag.NArg() {
+		dir = flag.Arg(0)
+	}
+
+	var name = *output
+
+	if 0 == len(name) {
+		name = filepath.Join(dir, strings.ToLower(types[0])+"_tranq.go")
+	}
+
+	var pkg, err = Parse(dir, types, *jsonTags, name)
+
+	if nil != err {
+		log.Fatal(err)
+	}
+
+	var (
+		generator = Generator{
+			Serializer: *serializer,
+			Command:    strings.Join(append([]string{"tranqgen"}, os.Args[1:]...), " "),
+		}
+		source []byte
+	)
+
+	if source, err = generator.Generate(pkg); nil != err {
+		log.Fatal(err)
+	} else if err = os.WriteFile(name, source, 0644); nil != err {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	exampleTypes = []string{"Post", "Comment", "Person", "Tag", "Summary"}
+)
+
+func generate(t *testing.T, source string, types ...string) ([]byte, error) {
+	var dir = t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(source), 0644); nil != err {
+		t.Fatal(err)
+	}
+
+	var pkg, err = Parse(dir, types, false, "")
+
+	if nil != err {
+		return nil, err
+	}
+
+	var generator = Generator{Serializer: "TranqSerializer", Command: "tranqgen"}
+
+	return generator.Generate(pkg)
+}
+
+func TestGenerateExample(t *testing.T) {
+	var pkg, err = Parse("example", exampleTypes, false, "post_tranq.go")
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var (
+		generator = Generator{
+			Serializer: "TranqSerializer",
+			Command:    "tranqgen -type " + strings.Join(exampleTypes, ","),
+		}
+		source, _   = generator.Generate(pkg)
+		expected, _ = os.ReadFile(filepath.Join("example", "post_tranq.go"))
+	)
+
+	assert.Equal(t, string(expected), string(source), "example/post_tranq.go is out of date, run go generate")
+}
+
+func TestParse(t *testing.T) {
+	var pkg, err = Parse("example", exampleTypes, false, "post_tranq.go")
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var post, summary = pkg.Resources[0], pkg.Resources[4]
+
+	assert.Equal(t, "example", pkg.Name)
+	assert.True(t, pkg.TagSensitive)
+	assert.Equal(t, "ID", post.ID.Name)
+	assert.Equal(t, "/posts", post.Self)
+	assert.True(t, post.MetaProvider)
+	assert.False(t, post.ContextMetaProvider)
+	assert.True(t, pkg.Resources[1].ContextMetaProvider)
+	assert.True(t, post.Linked)
+	assert.Equal(t, 9, len(post.Fields))
+	assert.Equal(t, "Revision", post.Meta[0].Name)
+	assert.Equal(t, "body", post.Fields[1].Tag.Name)
+	assert.Equal(t, "float64", post.Fields[2].Basic)
+	assert.Equal(t, "", post.Fields[4].Basic)
+	assert.Equal(t, &Link{Resource: pkg.Resources[2], Pointer: true}, post.Fields[6].Link)
+	assert.Equal(t, &Link{Resource: pkg.Resources[3], Pointer: true, Many: true}, post.Fields[8].Link)
+	assert.Nil(t, summary.ID)
+	assert.False(t, summary.Linked)
+}
+
+func TestParseJSONTags(t *testing.T) {
+	var pkg, err = Parse("example", exampleTypes, true, "post_tranq.go")
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "name", pkg.Resources[2].Fields[0].Tag.Name)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	var tests = []struct {
+		source string
+		err    string
+	}{
+		{"type Post struct{ ID int }", "type Missing not found in package models"},
+		{"type Missing int", "type Missing is not a struct type"},
+		{"type Missing struct{ ID int }\nfunc (m Missing) MarshalJSON() ([]byte, error) { return nil, nil }", "type Missing implements a marshaler"},
+		{"type Base struct{}\ntype Missing struct{ Base }", "type Missing: embedded field Base is unsupported"},
+		{"type Missing struct{ ID int; hidden string }", "type Missing: unexported field hidden must be ignored"},
+		{"type Missing struct{ ID int; Tags []string `tranq_link:\"true\"` }", "type Missing: linked field Tags must be a struct type"},
+		{"type Other struct{ Name string }\ntype Missing struct{ Other Other `tranq_link:\"true\"` }", "type Missing: linked field Other must be a struct type"},
+	}
+
+	for _, test := range tests {
+		var _, err = generate(t, "package models\n\n"+test.source+"\n", "Missing")
+
+		if assert.NotNil(t, err, test.source) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+	}
+
+	var _, err = generate(t, "package models\n\ntype Other struct{ Name string }\ntype Missing struct{ Other Other `tranq_link:\"true\"` }\n", "Missing", "Other")
+
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "type Other is linked but has no ID field", err.Error())
+	}
+}
+
+func TestGenerateIgnored(t *testing.T) {
+	var source, err = generate(t, "package models\n\ntype Person struct{\n\tID string `tranq:\"-\"`\n\tsecret string `tranq_ignore:\"true\"`\n\tCreated uint32 `tranq_meta:\"true\"`\n}\n", "Person")
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Contains(t, string(source), "if err = s.EnterDocumentKey([2]string{typ, v.ID}); nil != err {")
+	assert.Contains(t, string(source), "serializers.MergeMeta(meta, s.FormatAttributeName(\"Created\"), v.Created)")
+	assert.NotContains(t, string(source), "secret")
+	assert.NotContains(t, string(source), "\"math\"")
+}
+
+func TestGenerateJSONOmitEmpty(t *testing.T) {
+	var dir = t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte("package models\n\nimport \"time\"\n\ntype Post struct{\n\tID int\n\tCreated time.Time `json:\"created,omitempty\"`\n\tRatio float64 `json:\"ratio,omitempty\"`\n\tUpdated time.Time `tranq:\",omitempty\"`\n}\n"), 0644); nil != err {
+		t.Fatal(err)
+	}
+
+	var pkg, err = Parse(dir, []string{"Post"}, true, "")
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var (
+		generator = Generator{Serializer: "TranqSerializer", Command: "tranqgen"}
+		source, _ = generator.Generate(pkg)
+	)
+
+	assert.Contains(t, string(source), "if !serializers.IsEmptyJSONValue(v.Created) {")
+	assert.Contains(t, string(source), "if 0 != v.Ratio {")
+	assert.Contains(t, string(source), "if !s.IsZero(v.Updated) {")
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+var (
+	// basics contains the names of the predeclared types
+	// serialized as themselves by serializers.Base.
+	basics = map[string]bool{
+		"bool": true, "string": true, "byte": true, "rune": true,
+		"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+		"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+		"float32": true, "float64": true,
+	}
+)
+
+// Package describes the struct types of a
+// package passed to the -type flag.
+type Package struct {
+	// Name is the name of the package.
+	Name string
+	// Resources contains the struct types in
+	// the order they were passed.
+	Resources []*Resource
+	// JSONTags determines whether the `json` struct
+	// tag was read for fields without a `tranq` one.
+	JSONTags bool
+	// TagSensitive determines whether any field has a
+	// `json` struct tag but no `tranq` struct tag, making
+	// the output depend on UseJSONTags.
+	TagSensitive bool
+}
+
+// Resource describes a single struct type.
+type Resource struct {
+	// Name is the name of the type.
+	Name string
+	// ID is the identifier field, nil if
+	// the type has none.
+	ID *Field
+	// Fields contains the attribute and link fields in
+	// declaration order, excluding ignored fields, meta
+	// fields and the identifier field.
+	Fields []*Field
+	// Meta contains the fields marked with the
+	// TranqMeta struct tag.
+	Meta []*Field
+	// Self is the value of the first TranqSelf
+	// struct tag, if HasSelf is true.
+	Self    string
+	HasSelf bool
+	// MetaProvider determines whether the type or a
+	// pointer to it has a `TranqMeta` method, and
+	// ContextMetaProvider a `TranqMetaContext` method.
+	MetaProvider        bool
+	ContextMetaProvider bool
+	// Linked determines whether any field of
+	// the package links to the type.
+	Linked bool
+}
+
+// Field describes a single struct field.
+type Field struct {
+	// Name is the name of the field.
+	Name string
+	// Tag is the parsed Tag of the field.
+	Tag serializers.Tag
+	// Basic is the name of the field's type
+	// if it is a basic type.
+	Basic string
+	// Link describes the linked resources of fields
+	// marked with the TranqLink struct tag.
+	Link *Link
+	// Href is the value of the field's
+	// TranqHref struct tag.
+	Href string
+}
+
+// Link describes the resources of a linked field.
+type Link struct {
+	// Resource is the linked struct type.
+	Resource *Resource
+	// Pointer determines whether the field, or
+	// its elements, are pointers to the type.
+	Pointer bool
+	// Many determines whether the field is
+	// a slice or an array.
+	Many bool
+}
+
+// Parse reads the struct types named `types` from the Go
+// source files of the package in the directory `dir`,
+// skipping test files and the file `skip`. If `json` is
+// true the `json` struct tag is read for fields without a
+// `tranq` struct tag, as serializers.Base's UseJSONTags.
+func Parse(dir string, types []string, json bool, skip string) (*Package, error) {
+	var names, err = filepath.Glob(filepath.Join(dir, "*.go"))
+
+	if nil != err {
+		return nil, err
+	}
+
+	sort.Strings(names)
+
+	var (
+		fset    = token.NewFileSet()
+		pkg     = &Package{JSONTags: json}
+		specs   = make(map[string]*ast.TypeSpec)
+		methods = make(map[string]map[string]bool)
+	)
+
+	for i := 0; i < len(names); i++ {
+		if strings.HasSuffix(names[i], "_test.go") || filepath.Base(names[i]) == filepath.Base(skip) {
+			continue
+		}
+
+		var file, err = parser.ParseFile(fset, names[i], nil, 0)
+
+		if nil != err {
+			return nil, err
+		} else if 0 == len(pkg.Name) {
+			pkg.Name = file.Name.Name
+		} else if pkg.Name != file.Name.Name {
+			return nil, fmt.Errorf("found packages %s and %s in %s", pkg.Name, file.Name.Name, dir)
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok {
+						specs[spec.Name.Name] = spec
+					}
+				}
+			case *ast.FuncDecl:
+				if receiver := receiverName(decl); 0 < len(receiver) {
+					if nil == methods[receiver] {
+						methods[receiver] = make(map[string]bool)
+					}
+
+					methods[receiver][decl.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	if 0 == len(pkg.Name) {
+		return nil, fmt.Errorf("no Go source files in %s", dir)
+	}
+
+	var resources = make(map[string]*Resource)
+
+	for i := 0; i < len(types); i++ {
+		var spec, ok = specs[types[i]]
+
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in package %s", types[i], pkg.Name)
+		} else if _, ok = spec.Type.(*ast.StructType); !ok || spec.Assign.IsValid() || nil != spec.TypeParams {
+			return nil, fmt.Errorf("type %s is not a struct type", types[i])
+		} else if methods[types[i]]["MarshalJSON"] || methods[types[i]]["MarshalText"] {
+			return nil, fmt.Errorf("type %s implements a marshaler and is serialized as a leaf value", types[i])
+		}
+
+		var resource = &Resource{
+			Name:                types[i],
+			MetaProvider:        methods[types[i]]["TranqMeta"],
+			ContextMetaProvider: methods[types[i]]["TranqMetaContext"],
+		}
+
+		resources[resource.Name] = resource
+		pkg.Resources = append(pkg.Resources, resource)
+	}
+
+	for _, resource := range pkg.Resources {
+		if err = pkg.parseResource(resource, specs[resource.Name].Type.(*ast.StructType), specs, resources); nil != err {
+			return nil, err
+		}
+	}
+
+	for _, resource := range pkg.Resources {
+		if resource.Linked && nil == resource.ID {
+			return nil, fmt.Errorf("type %s is linked but has no %s field", resource.Name, serializers.ID)
+		}
+	}
+
+	return pkg, nil
+}
+
+// parseResource reads the fields of the struct type `s`
+// into the Resource `r`, classifying them as
+// serializers.Base's TypeMetadata does.
+func (p *Package) parseResource(r *Resource, s *ast.StructType, specs map[string]*ast.TypeSpec, resources map[string]*Resource) error {
+	for _, decl := range s.Fields.List {
+		if 0 == len(decl.Names) {
+			return fmt.Errorf("type %s: embedded field %s is unsupported", r.Name, describe(decl.Type))
+		}
+
+		var tag reflect.StructTag
+
+		if nil != decl.Tag {
+			var value, err = strconv.Unquote(decl.Tag.Value)
+
+			if nil != err {
+				return err
+			}
+
+			tag = reflect.StructTag(value)
+		}
+
+		for _, name := range decl.Names {
+			var field = reflect.StructField{Name: name.Name, Tag: tag}
+
+			if template, ok := tag.Lookup(serializers.TranqSelf); ok && !r.HasSelf {
+				r.Self, r.HasSelf = template, true
+			}
+
+			if _, ok := tag.Lookup(serializers.Tranq); !ok {
+				if _, ok = tag.Lookup(serializers.JSON); ok {
+					p.TagSensitive = true
+				}
+			}
+
+			var f = &Field{
+				Name:  name.Name,
+				Tag:   serializers.ParseTag(field),
+				Basic: basic(decl.Type, specs),
+				Href:  tag.Get(serializers.TranqHref),
+			}
+
+			if _, ok := tag.Lookup(serializers.Tranq); !ok && p.JSONTags {
+				f.Tag = serializers.ParseJSONTag(field)
+			}
+
+			if serializers.ID == name.Name {
+				r.ID = f
+			}
+
+			if f.Tag.Ignore {
+				continue
+			} else if !name.IsExported() {
+				return fmt.Errorf("type %s: unexported field %s must be ignored", r.Name, name.Name)
+			} else if serializers.IsMeta(field) {
+				r.Meta = append(r.Meta, f)
+				continue
+			} else if r.ID == f {
+				continue
+			} else if "true" == tag.Get(serializers.TranqLink) {
+				var link, ok = parseLink(decl.Type, resources)
+
+				if !ok {
+					return fmt.Errorf("type %s: linked field %s must be a struct type passed to -type, a pointer to one or a slice or array of either", r.Name, name.Name)
+				}
+
+				f.Link = link
+				link.Resource.Linked = true
+			}
+
+			r.Fields = append(r.Fields, f)
+		}
+	}
+
+	return nil
+}
+
+// parseLink returns the Link described by the type
+// expression `e`, reporting false if it does not
+// refer to one of the `resources`.
+func parseLink(e ast.Expr, resources map[string]*Resource) (*Link, bool) {
+	var link = new(Link)
+
+	if array, ok := e.(*ast.ArrayType); ok {
+		link.Many, e = true, array.Elt
+	}
+
+	if star, ok := e.(*ast.StarExpr); ok {
+		link.Pointer, e = true, star.X
+	}
+
+	if ident, ok := e.(*ast.Ident); ok {
+		link.Resource = resources[ident.Name]
+	}
+
+	return link, nil != link.Resource
+}
+
+// basic returns the name of the type expression `e`
+// if it is a predeclared basic type not redeclared
+// by the package.
+func basic(e ast.Expr, specs map[string]*ast.TypeSpec) string {
+	var ident, ok = e.(*ast.Ident)
+
+	if !ok || !basics[ident.Name] {
+		return ""
+	} else if _, ok = specs[ident.Name]; ok {
+		return ""
+	}
+
+	return ident.Name
+}
+
+// receiverName returns the name of the type of the receiver
+// of the method `f`, or an empty string for functions.
+func receiverName(f *ast.FuncDecl) string {
+	if nil == f.Recv || 0 == len(f.Recv.List) {
+		return ""
+	}
+
+	var e = f.Recv.List[0].Type
+
+	if star, ok := e.(*ast.StarExpr); ok {
+		e = star.X
+	}
+
+	if ident, ok := e.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return ""
+}
+
+// describe returns a description of the type
+// expression `e` for error messages.
+func describe(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return "*" + describe(e.X)
+	case *ast.SelectorExpr:
+		return describe(e.X) + "." + e.Sel.Name
+	}
+
+	return fmt.Sprintf("%T", e)
+}
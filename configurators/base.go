@@ -3,6 +3,7 @@ package configurators
 import "sync"
 
 import (
+	"github.com/chuckpreslar/tranq/deserializers"
 	"github.com/chuckpreslar/tranq/serializers"
 )
 
@@ -23,10 +24,22 @@ const (
 	Href = "href"
 	// Type represents the JSON API reserved string "type"
 	Type = "type"
+	// Attributes represents the JSON API reserved string "attributes"
+	Attributes = "attributes"
+	// Relationships represents the JSON API reserved string "relationships"
+	Relationships = "relationships"
+	// Included represents the JSON API reserved string "included"
+	Included = "included"
+	// Related represents the JSON API reserved string "related"
+	Related = "related"
+	// Self represents the JSON API reserved string "self"
+	Self = "self"
 )
 
-// Base is a type implmenting the Configurator interface.
-type Base struct {
+// Settings contains the settings of a configurator. They are
+// copied by NewBase and NewV1 when the configurator is created,
+// so changing a Settings afterwards has no effect on it.
+type Settings struct {
 	// TypeNameFormatter is used to format names of
 	// types during serialization. Types include
 	// base language types as well as developer
@@ -41,50 +54,165 @@ type Base struct {
 	// `href` attribute value when linked resources
 	// are encountered during serialization.
 	HrefFormatter serializers.HrefFormatter
-	// ReservedStrings is a structure containing
-	// JSON API reserved words formatted with the
+	// SelfTemplates contains the URL templates of the
+	// `self` links of resources keyed by formatted type
+	// name, overriding the `tranq_self` struct tag.
+	SelfTemplates map[string]string
+	// LinkTemplates determines whether serializers.Base
+	// also describes relationships by URL templates under
+	// the top level `links` member, as JSON API drafts
+	// allowed. It has no effect on the 1.0 format.
+	LinkTemplates bool
+	// FormatMapKeys determines whether keys of
+	// serialized maps are formatted with the
 	// AttributeNameFormatter NamingFormatter.
-	ReservedStrings struct {
-		ID     string
-		IDs    string
-		Links  string
-		Linked string
-		Meta   string
-		Data   string
-		Type   string
-		Href   string
+	FormatMapKeys bool
+	// UseJSONTags determines whether the `json` struct
+	// tag is read for attribute names and options when
+	// a field has no `tranq` struct tag, allowing one
+	// set of tags to drive both encoders.
+	UseJSONTags bool
+	// IdentifierStrategy is used to locate the
+	// identifiers of resources during serialization
+	// and deserialization. If nil, the field named
+	// by serializers.ID is used.
+	IdentifierStrategy serializers.IdentifierStrategy
+	// MaxDepth is the maximum number of resources nested
+	// along a single path during serialization, past which
+	// linked resources are linked by reference only. If
+	// zero, serializers.DefaultMaxDepth is used.
+	MaxDepth int
+	// ConflictPolicy determines how a linked resource
+	// appearing more than once within a document with
+	// differing values is resolved during serialization.
+	ConflictPolicy serializers.ConflictPolicy
+}
+
+// Base is a type implmenting the Configurator interface.
+// It is created from Settings by NewBase; the zero value
+// uses the zero Settings. Every serializer and deserializer
+// it creates shares its settings, and serializers share
+// the reflection metadata they cache.
+type Base struct {
+	// config contains the settings copied
+	// by NewBase, nil for the zero value.
+	config *settings
+}
+
+// settings contains the settings of a configurator,
+// never modified once created by newSettings.
+type settings struct {
+	// serializer is copied by each
+	// serializer created.
+	serializer serializers.Base
+	// deserializer is copied by each
+	// deserializer created.
+	deserializer deserializers.Base
+}
+
+// defaults contains the settings of configurators
+// created without NewBase or NewV1, created once
+// from the zero Settings.
+var defaults struct {
+	config *settings
+	once   sync.Once
+}
+
+// NewBase returns a new instance of the Base type
+// with a copy of the Settings `s`.
+func NewBase(s Settings) *Base {
+	return &Base{newSettings(s)}
+}
+
+// newSettings returns the settings of a configurator created
+// from the Settings `s`, formatting the JSON API reserved
+// strings with its AttributeNameFormatter. Serializers created
+// from them share a single serializers.TypeCache.
+func newSettings(s Settings) *settings {
+	var templates map[string]string
+
+	if nil != s.SelfTemplates {
+		templates = make(map[string]string, len(s.SelfTemplates))
+
+		for name, template := range s.SelfTemplates {
+			templates[name] = template
+		}
+	}
+
+	var config = &settings{
+		serializer: serializers.Base{
+			TypeNameFormatter:      s.TypeNameFormatter,
+			AttributeNameFormatter: s.AttributeNameFormatter,
+			HrefFormatter:          s.HrefFormatter,
+			SelfTemplates:          templates,
+			LinkTemplates:          s.LinkTemplates,
+			MaxDepth:               s.MaxDepth,
+			ConflictPolicy:         s.ConflictPolicy,
+			TypeCache:              new(serializers.TypeCache),
+			FormatMapKeys:          s.FormatMapKeys,
+			UseJSONTags:            s.UseJSONTags,
+			IdentifierStrategy:     s.IdentifierStrategy,
+		},
+		deserializer: deserializers.Base{
+			TypeNameFormatter:      s.TypeNameFormatter,
+			AttributeNameFormatter: s.AttributeNameFormatter,
+			UseJSONTags:            s.UseJSONTags,
+			IdentifierStrategy:     s.IdentifierStrategy,
+		},
 	}
-	// mutex prevents mutation of exposed fields
-	// used to create instances of `serlizers.Serializer`
-	mutex sync.Mutex
+
+	var reserved = &config.serializer.ReservedStrings
+
+	reserved.ID = formatName(s.AttributeNameFormatter, ID)
+	reserved.IDs = formatName(s.AttributeNameFormatter, IDs)
+	reserved.Links = formatName(s.AttributeNameFormatter, Links)
+	reserved.Linked = formatName(s.AttributeNameFormatter, Linked)
+	reserved.Meta = formatName(s.AttributeNameFormatter, Meta)
+	reserved.Data = formatName(s.AttributeNameFormatter, Data)
+	reserved.Type = formatName(s.AttributeNameFormatter, Type)
+	reserved.Href = formatName(s.AttributeNameFormatter, Href)
+	reserved.Attributes = formatName(s.AttributeNameFormatter, Attributes)
+	reserved.Relationships = formatName(s.AttributeNameFormatter, Relationships)
+	reserved.Included = formatName(s.AttributeNameFormatter, Included)
+	reserved.Related = formatName(s.AttributeNameFormatter, Related)
+	reserved.Self = formatName(s.AttributeNameFormatter, Self)
+
+	config.deserializer.ReservedStrings = config.serializer.ReservedStrings
+
+	return config
+}
+
+// configure returns Base's settings, or those
+// of the zero Settings for the zero value.
+func (b *Base) configure() *settings {
+	if nil != b.config {
+		return b.config
+	}
+
+	defaults.once.Do(func() {
+		defaults.config = newSettings(Settings{})
+	})
+
+	return defaults.config
 }
 
 // NewSerializer implements the Configurator interface
 // returning an instance of the Serializer interface
 // implmented by serializers.Base.
 func (b *Base) NewSerializer() serializers.Serializer {
-	b.mutex.Lock()
-
-	b.ReservedStrings.ID = b.FormatAttributeName(ID)
-	b.ReservedStrings.IDs = b.FormatAttributeName(IDs)
-	b.ReservedStrings.Links = b.FormatAttributeName(Links)
-	b.ReservedStrings.Linked = b.FormatAttributeName(Linked)
-	b.ReservedStrings.Meta = b.FormatAttributeName(Meta)
-	b.ReservedStrings.Data = b.FormatAttributeName(Data)
-	b.ReservedStrings.Type = b.FormatAttributeName(Type)
-	b.ReservedStrings.Href = b.FormatAttributeName(Href)
-
-	var serializer = &serializers.Base{
-		TypeNameFormatter:      b.TypeNameFormatter,
-		AttributeNameFormatter: b.AttributeNameFormatter,
-		HrefFormatter:          b.HrefFormatter,
-		ReservedStrings:        b.ReservedStrings,
-		LinkedDocuments:        make(map[interface{}]struct{}),
-	}
+	var serializer = b.configure().serializer
+
+	return &serializer
+}
 
-	b.mutex.Unlock()
+// NewDeserializer returns an instance of the
+// deserializers.Deserializer interface implemented
+// by deserializers.Base, reading documents in the
+// format produced by NewSerializer.
+func (b *Base) NewDeserializer() deserializers.Deserializer {
+	var deserializer = b.configure().deserializer
 
-	return serializer
+	return &deserializer
 }
 
 // FormatAttributeName allows access to Base's
@@ -92,11 +220,7 @@ func (b *Base) NewSerializer() serializers.Serializer {
 // AttributeNameFormatter was provided, the original
 // string is returned in place of a formatted one.
 func (b *Base) FormatAttributeName(s string) string {
-	if nil == b.AttributeNameFormatter {
-		return s
-	}
-
-	return b.AttributeNameFormatter.FormatName(s)
+	return formatName(b.configure().serializer.AttributeNameFormatter, s)
 }
 
 // FormatTypeName allows access to Base's
@@ -104,9 +228,15 @@ func (b *Base) FormatAttributeName(s string) string {
 // TypeNameFormatter was provided, the original
 // string is returned in place of a formatted one.
 func (b *Base) FormatTypeName(s string) string {
-	if nil == b.TypeNameFormatter {
+	return formatName(b.configure().serializer.TypeNameFormatter, s)
+}
+
+// formatName formats the string `s` with the NamingFormatter
+// `f`, returning it unformatted if `f` is nil.
+func formatName(f serializers.NamingFormatter, s string) string {
+	if nil == f {
 		return s
 	}
 
-	return b.TypeNameFormatter.FormatName(s)
+	return f.FormatName(s)
 }
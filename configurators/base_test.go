@@ -1,11 +1,14 @@
 package configurators_test
 
 import (
+	"strings"
+	"sync"
 	"testing"
 )
 
 import (
 	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/deserializers"
 	"github.com/chuckpreslar/tranq/serializers"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,7 +18,7 @@ func TestNewSerializer(t *testing.T) {
 		typ    = "type"
 		attr   = "attribute"
 		href   = "href"
-		config = configurators.Base{
+		config = configurators.NewBase(configurators.Settings{
 			TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
 				return typ
 			}),
@@ -25,42 +28,72 @@ func TestNewSerializer(t *testing.T) {
 			HrefFormatter: serializers.HrefFormatterFunc(func(h, o, c string, i []interface{}) string {
 				return href
 			}),
-		}
+		})
 		serializer = config.NewSerializer()
 	)
 
 	assert.NotNil(t, serializer, "failed to return instance of serializers.Serializer interface")
 	assert.Implements(t, (*serializers.Serializer)(nil), serializer, "failed to return instance of serializers.Serializer interface")
 
-	var s = []string{
-		config.ReservedStrings.ID,
-		config.ReservedStrings.IDs,
-		config.ReservedStrings.Links,
-		config.ReservedStrings.Linked,
-		config.ReservedStrings.Meta,
-		config.ReservedStrings.Data,
-		config.ReservedStrings.Type,
-		config.ReservedStrings.Href,
-	}
+	var (
+		reserved = serializer.(*serializers.Base).ReservedStrings
+		s        = []string{
+			reserved.ID,
+			reserved.IDs,
+			reserved.Links,
+			reserved.Linked,
+			reserved.Meta,
+			reserved.Data,
+			reserved.Type,
+			reserved.Href,
+		}
+	)
 
 	for i := 0; i < len(s); i++ {
 		assert.Equal(t, attr, s[i], "failed to map ReservedStrings with supplied AttributeNameFormatter")
 	}
 }
 
+func TestNewDeserializer(t *testing.T) {
+	var (
+		attr   = "attribute"
+		config = configurators.NewBase(configurators.Settings{
+			AttributeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
+				return attr
+			}),
+		})
+		deserializer = config.NewDeserializer()
+	)
+
+	assert.NotNil(t, deserializer, "failed to return instance of deserializers.Deserializer interface")
+	assert.Implements(t, (*deserializers.Deserializer)(nil), deserializer, "failed to return instance of deserializers.Deserializer interface")
+	assert.Equal(t, attr, deserializer.(*deserializers.Base).ReservedStrings.Links, "failed to map ReservedStrings with supplied AttributeNameFormatter")
+}
+
+func TestNewSerializerUseJSONTags(t *testing.T) {
+	var config = configurators.NewBase(configurators.Settings{UseJSONTags: true, FormatMapKeys: true})
+
+	var serializer = config.NewSerializer().(*serializers.Base)
+	var deserializer = config.NewDeserializer().(*deserializers.Base)
+
+	assert.True(t, serializer.UseJSONTags, "failed to pass UseJSONTags to serializers.Base")
+	assert.True(t, serializer.FormatMapKeys, "failed to pass FormatMapKeys to serializers.Base")
+	assert.True(t, deserializer.UseJSONTags, "failed to pass UseJSONTags to deserializers.Base")
+}
+
 func TestFormatAttributeName(t *testing.T) {
 	var (
 		attr   = "attribute"
 		test   = "test"
-		config = configurators.Base{
+		config = configurators.NewBase(configurators.Settings{
 			AttributeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
 				return attr
 			}),
-		}
+		})
 	)
 
 	assert.Equal(t, attr, config.FormatAttributeName(test), "failed to format string with supplied AttributeNameFormatter")
-	config.AttributeNameFormatter = nil
+	config = new(configurators.Base)
 	assert.Equal(t, test, config.FormatAttributeName(test), "failed to return default value when no AttributeNameFormatter supplied")
 }
 
@@ -68,14 +101,125 @@ func TestFormatTypeName(t *testing.T) {
 	var (
 		typ    = "type"
 		test   = "test"
-		config = configurators.Base{
+		config = configurators.NewBase(configurators.Settings{
 			TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
 				return typ
 			}),
-		}
+		})
 	)
 
 	assert.Equal(t, typ, config.FormatTypeName(test), "failed to format string with supplied TypeNameFormatter")
-	config.TypeNameFormatter = nil
+	config = new(configurators.Base)
 	assert.Equal(t, test, config.FormatTypeName(test), "failed to return default value when no TypeNameFormatter supplied")
 }
+
+func TestNewSerializerIdentifierStrategy(t *testing.T) {
+	var (
+		strategy = serializers.TagIdentifier(serializers.Tranq)
+		config   = configurators.NewBase(configurators.Settings{IdentifierStrategy: strategy})
+	)
+
+	var serializer = config.NewSerializer().(*serializers.Base)
+	var deserializer = config.NewDeserializer().(*deserializers.Base)
+
+	assert.Equal(t, strategy, serializer.IdentifierStrategy, "failed to pass IdentifierStrategy to serializers.Base")
+	assert.Equal(t, strategy, deserializer.IdentifierStrategy, "failed to pass IdentifierStrategy to deserializers.Base")
+}
+
+func TestNewSerializerMaxDepth(t *testing.T) {
+	var config = configurators.NewBase(configurators.Settings{MaxDepth: 4})
+
+	assert.Equal(t, 4, config.NewSerializer().(*serializers.Base).MaxDepth, "failed to pass MaxDepth to serializers.Base")
+}
+
+func TestNewSerializerConflictPolicy(t *testing.T) {
+	var config = configurators.NewBase(configurators.Settings{ConflictPolicy: serializers.ConflictMerge})
+
+	assert.Equal(t, serializers.ConflictMerge, config.NewSerializer().(*serializers.Base).ConflictPolicy, "failed to pass ConflictPolicy to serializers.Base")
+}
+
+func TestNewSerializerSelfTemplates(t *testing.T) {
+	var (
+		templates = map[string]string{"posts": "/api/posts"}
+		config    = configurators.NewBase(configurators.Settings{SelfTemplates: templates})
+	)
+
+	assert.Equal(t, templates, config.NewSerializer().(*serializers.Base).SelfTemplates, "failed to pass SelfTemplates to serializers.Base")
+}
+
+func TestNewSerializerLinkTemplates(t *testing.T) {
+	var config = configurators.NewBase(configurators.Settings{LinkTemplates: true})
+
+	assert.True(t, config.NewSerializer().(*serializers.Base).LinkTemplates, "failed to pass LinkTemplates to serializers.Base")
+}
+
+func TestNewSerializerTypeCache(t *testing.T) {
+	var (
+		config = new(configurators.Base)
+		first  = config.NewSerializer().(*serializers.Base)
+		second = config.NewSerializer().(*serializers.Base)
+	)
+
+	assert.NotNil(t, first.TypeCache, "failed to pass TypeCache to serializers.Base")
+	assert.True(t, first.TypeCache == second.TypeCache, "failed to share TypeCache across serializers")
+}
+
+func TestNewBaseCopiesSettings(t *testing.T) {
+	var (
+		templates = map[string]string{"posts": "/api/posts"}
+		settings  = configurators.Settings{
+			AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToUpper),
+			SelfTemplates:          templates,
+		}
+		config = configurators.NewBase(settings)
+		first  = config.NewSerializer().(*serializers.Base)
+	)
+
+	settings.AttributeNameFormatter = nil
+	settings.MaxDepth = 4
+	templates["posts"] = "/v2/posts"
+
+	var (
+		second       = config.NewSerializer().(*serializers.Base)
+		deserializer = config.NewDeserializer().(*deserializers.Base)
+	)
+
+	assert.Equal(t, "LINKS", second.ReservedStrings.Links, "failed to format ReservedStrings with copied AttributeNameFormatter")
+	assert.Equal(t, "LINKS", deserializer.ReservedStrings.Links, "failed to format ReservedStrings with copied AttributeNameFormatter")
+	assert.Equal(t, 0, second.MaxDepth, "observed Settings changed after NewBase")
+	assert.Equal(t, map[string]string{"posts": "/api/posts"}, second.SelfTemplates, "observed SelfTemplates changed after NewBase")
+	assert.False(t, first == second, "failed to return a new serializers.Base")
+}
+
+func TestBaseZeroValue(t *testing.T) {
+	var (
+		first  = new(configurators.Base).NewSerializer().(*serializers.Base)
+		second = new(configurators.V1).NewSerializer().(*serializers.V1)
+	)
+
+	assert.Equal(t, configurators.Links, first.ReservedStrings.Links, "failed to map ReservedStrings of zero value")
+	assert.True(t, first.TypeCache == second.TypeCache, "failed to share TypeCache of zero Settings")
+	assert.False(t, first.TypeCache == configurators.NewBase(configurators.Settings{}).NewSerializer().(*serializers.Base).TypeCache, "shared TypeCache with configurator created by NewBase")
+}
+
+func TestNewSerializerConcurrent(t *testing.T) {
+	var (
+		config = &configurators.V1{}
+		group  sync.WaitGroup
+	)
+
+	for i := 0; i < 8; i++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			var serializer = config.NewSerializer().(*serializers.V1)
+
+			assert.Equal(t, configurators.Data, serializer.ReservedStrings.Data, "failed to map ReservedStrings")
+			assert.NotNil(t, config.NewDeserializer(), "failed to return instance of deserializers.Deserializer interface")
+		}()
+	}
+
+	group.Wait()
+}
@@ -1,6 +1,7 @@
 package configurators
 
 import (
+	"github.com/chuckpreslar/tranq/deserializers"
 	"github.com/chuckpreslar/tranq/serializers"
 )
 
@@ -12,3 +13,12 @@ type Configurator interface {
 	// serializers.Serializer interface.
 	NewSerializer() serializers.Serializer
 }
+
+// DeserializingConfigurator interface provides the
+// ability to create and customize types implementing
+// the deserializers.Deserializer interface.
+type DeserializingConfigurator interface {
+	// NewDeserializer returns an instance of the
+	// deserializers.Deserializer interface.
+	NewDeserializer() deserializers.Deserializer
+}
@@ -0,0 +1,35 @@
+package configurators
+
+import (
+	"github.com/chuckpreslar/tranq/deserializers"
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+// V1 is a type implementing the Configurator interface,
+// creating serializers producing documents in the format
+// specified by JSON API 1.0. It is created from Settings
+// by NewV1; the zero value uses the zero Settings.
+type V1 struct {
+	Base
+}
+
+// NewV1 returns a new instance of the V1 type
+// with a copy of the Settings `s`.
+func NewV1(s Settings) *V1 {
+	return &V1{*NewBase(s)}
+}
+
+// NewSerializer implements the Configurator interface
+// returning an instance of the Serializer interface
+// implemented by serializers.V1.
+func (v *V1) NewSerializer() serializers.Serializer {
+	return &serializers.V1{Base: v.configure().serializer}
+}
+
+// NewDeserializer returns an instance of the
+// deserializers.Deserializer interface implemented
+// by deserializers.V1, reading documents in the
+// format produced by NewSerializer.
+func (v *V1) NewDeserializer() deserializers.Deserializer {
+	return &deserializers.V1{Base: v.configure().deserializer}
+}
@@ -0,0 +1,41 @@
+package configurators_test
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/deserializers"
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV1NewSerializer(t *testing.T) {
+	var (
+		config     = configurators.V1{}
+		serializer = config.NewSerializer()
+	)
+
+	assert.IsType(t, &serializers.V1{}, serializer, "failed to return instance of serializers.V1")
+
+	var s = serializer.(*serializers.V1)
+
+	assert.Equal(t, configurators.Data, s.ReservedStrings.Data, "failed to map ReservedStrings")
+	assert.Equal(t, configurators.Attributes, s.ReservedStrings.Attributes, "failed to map ReservedStrings")
+	assert.Equal(t, configurators.Relationships, s.ReservedStrings.Relationships, "failed to map ReservedStrings")
+	assert.Equal(t, configurators.Included, s.ReservedStrings.Included, "failed to map ReservedStrings")
+}
+
+func TestV1NewDeserializer(t *testing.T) {
+	var config = configurators.V1{}
+
+	assert.IsType(t, &deserializers.V1{}, config.NewDeserializer(), "failed to return instance of deserializers.V1")
+}
+
+func TestNewV1(t *testing.T) {
+	var config = configurators.NewV1(configurators.Settings{MaxDepth: 4})
+
+	assert.Equal(t, 4, config.NewSerializer().(*serializers.V1).MaxDepth, "failed to pass Settings to serializers.V1")
+	assert.IsType(t, &deserializers.V1{}, config.NewDeserializer(), "failed to return instance of deserializers.V1")
+}
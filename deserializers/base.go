@@ -0,0 +1,753 @@
+package deserializers
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+// InvalidTargetError occurs when the go object provided
+// to a Deserializer is not a non-nil pointer.
+type InvalidTargetError struct {
+	Value reflect.Value
+}
+
+// Error implements the `error` interface for the
+// InvalidTargetError type.
+func (i InvalidTargetError) Error() string {
+	return fmt.Sprintf("value `%s` must be a non-nil pointer to be deserialized into", i.Value)
+}
+
+// MissingNamespaceError occurs when a document does
+// not contain the root level namespace expected for
+// the go object being deserialized into.
+type MissingNamespaceError struct {
+	Namespace string
+}
+
+// Error implements the `error` interface for the
+// MissingNamespaceError type.
+func (m MissingNamespaceError) Error() string {
+	return fmt.Sprintf("document is missing root level namespace `%s`", m.Namespace)
+}
+
+// MismatchedValueError occurs when a value contained
+// in a document cannot be assigned to the go type
+// it is being deserialized into.
+type MismatchedValueError struct {
+	Value interface{}
+	Type  reflect.Type
+}
+
+// Error implements the `error` interface for the
+// MismatchedValueError type.
+func (m MismatchedValueError) Error() string {
+	return fmt.Sprintf("cannot deserialize value `%v` of type `%T` into type `%s`", m.Value, m.Value, m.Type)
+}
+
+// UnsupportedKindError occurs when a Deserializer encounters
+// a reflect.Kind it does not have the ability to populate.
+type UnsupportedKindError struct {
+	Kind         reflect.Kind
+	Deserializer Deserializer
+}
+
+// Error implements the `error` interface for the
+// UnsupportedKindError type.
+func (u UnsupportedKindError) Error() string {
+	return fmt.Sprintf("encountered a value with reflect.Kind of `%s` is unsupported by the deserializer `%T`", u.Kind, u.Deserializer)
+}
+
+// Base is a type implementing the Deserializer interface,
+// reading documents in the format produced by serializers.Base.
+type Base struct {
+	// TypeNameFormatter is used to format names of
+	// types during deserialization, locating the
+	// root level namespace and linked documents.
+	TypeNameFormatter serializers.NamingFormatter
+	// AttributeNameFormatter is used to format names of
+	// struct fields during deserialization, locating
+	// their attributes within a document.
+	AttributeNameFormatter serializers.NamingFormatter
+	// UseJSONTags determines whether the `json` struct
+	// tag is read for attribute names and options when
+	// a field has no `tranq` struct tag.
+	UseJSONTags bool
+	// IdentifierStrategy is used to locate the fields
+	// identifiers are deserialized into. If nil,
+	// serializers.DefaultIdentifierStrategy is used.
+	IdentifierStrategy serializers.IdentifierStrategy
+	// RootContext is the JSON API document currently
+	// being deserialized.
+	RootContext map[string]interface{}
+	// LinkedDocuments contains the linked documents
+	// currently being deserialized, preventing
+	// resources that link to each other from
+	// recursing indefinitely.
+	LinkedDocuments map[string]struct{}
+	// ReservedStrings is a structure containing
+	// JSON API reserved words formatted with the
+	// AttributeNameFormatter NamingFormatter.
+	ReservedStrings struct {
+		ID            string
+		IDs           string
+		Links         string
+		Linked        string
+		Meta          string
+		Data          string
+		Type          string
+		Href          string
+		Attributes    string
+		Relationships string
+		Included      string
+		Related       string
+		Self          string
+	}
+}
+
+// Accept implements the `Accept` method required
+// by the Deserializer interface, deserializing `m`
+// into `i` with a Fork of Base.
+func (b *Base) Accept(m map[string]interface{}, i interface{}) error {
+	return b.Fork().accept(m, i)
+}
+
+// Fork returns a copy of Base sharing its settings with no
+// document being deserialized, deserializing a single document
+// without modifying Base. Accept forks Base on every call, so a
+// Base may serve concurrent calls as long as its settings are
+// not changed.
+func (b *Base) Fork() *Base {
+	var fork = *b
+	fork.RootContext = nil
+	fork.LinkedDocuments = nil
+
+	return &fork
+}
+
+// accept deserializes `m` into `i` as Accept does,
+// modifying Base's RootContext and LinkedDocuments.
+func (b *Base) accept(m map[string]interface{}, i interface{}) (err error) {
+	var (
+		value     = reflect.ValueOf(i)
+		namespace string
+		document  interface{}
+		ok        bool
+	)
+
+	defer func() {
+		if temp := recover(); nil != temp {
+			if _, ok := temp.(error); ok {
+				err = temp.(error)
+			} else {
+				err = fmt.Errorf("%s", temp)
+			}
+		}
+	}()
+
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return InvalidTargetError{value}
+	}
+
+	if namespace, err = serializers.TypeName(value.Type()); nil != err {
+		return err
+	}
+
+	namespace = b.FormatTypeName(namespace)
+
+	if document, ok = m[namespace]; !ok {
+		return MissingNamespaceError{namespace}
+	}
+
+	b.RootContext = m
+	b.LinkedDocuments = make(map[string]struct{})
+
+	return b.Deserialize(document, value.Elem())
+}
+
+// Deserialize allows for the recursive deserialization
+// of a document's values into base and user defined types.
+func (b *Base) Deserialize(i interface{}, v reflect.Value) error {
+	if nil == i {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	} else if ok, err := b.DeserializeUnmarshaler(i, v); ok {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return b.DeserializeBool(i, v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return b.DeserializeInt(i, v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return b.DeserializeUint(i, v)
+	case reflect.Float32, reflect.Float64:
+		return b.DeserializeFloat(i, v)
+	case reflect.String:
+		return b.DeserializeString(i, v)
+	case reflect.Interface:
+		return b.DeserializeInterface(i, v)
+	case reflect.Ptr:
+		return b.DeserializePtr(i, v)
+	case reflect.Array, reflect.Slice:
+		return b.DeserializeSlice(i, v)
+	case reflect.Map:
+		return b.DeserializeMap(i, v)
+	case reflect.Struct:
+		return b.DeserializeStruct(i, v)
+	}
+
+	return UnsupportedKindError{v.Kind(), b}
+}
+
+// DeserializeUnmarshaler attempts to deserialize a value into an
+// addressable reflect.Value implementing json.Unmarshaler or
+// encoding.TextUnmarshaler through a pointer receiver, such as
+// time.Time. The returned boolean reports whether `v` was
+// handled as an unmarshaler.
+func (b *Base) DeserializeUnmarshaler(i interface{}, v reflect.Value) (bool, error) {
+	if !v.CanAddr() || v.Kind() == reflect.Interface {
+		return false, nil
+	}
+
+	var value = reflect.ValueOf(i)
+
+	switch target := v.Addr().Interface().(type) {
+	case json.Unmarshaler:
+		if value.Type() == v.Type() {
+			v.Set(value)
+			return true, nil
+		}
+
+		var data, err = json.Marshal(i)
+
+		if nil != err {
+			return true, err
+		}
+
+		return true, target.UnmarshalJSON(data)
+	case encoding.TextUnmarshaler:
+		if value.Type() == v.Type() {
+			v.Set(value)
+			return true, nil
+		} else if value.Kind() != reflect.String {
+			return true, MismatchedValueError{i, v.Type()}
+		}
+
+		return true, target.UnmarshalText([]byte(value.String()))
+	}
+
+	return false, nil
+}
+
+// DeserializeBool attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Bool.
+func (b *Base) DeserializeBool(i interface{}, v reflect.Value) error {
+	var value = reflect.ValueOf(i)
+
+	if value.Kind() != reflect.Bool {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	v.SetBool(value.Bool())
+
+	return nil
+}
+
+// DeserializeInt attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Int,
+// reflect.Int8, reflect.Int16, reflect.Int32 or reflect.Int64.
+func (b *Base) DeserializeInt(i interface{}, v reflect.Value) error {
+	var (
+		value = reflect.ValueOf(i)
+		n     int64
+	)
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.Uint() > math.MaxInt64 {
+			return MismatchedValueError{i, v.Type()}
+		}
+
+		n = int64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		if value.Float() != math.Trunc(value.Float()) {
+			return MismatchedValueError{i, v.Type()}
+		}
+
+		n = int64(value.Float())
+	default:
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	if v.OverflowInt(n) {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	v.SetInt(n)
+
+	return nil
+}
+
+// DeserializeUint attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Uint,
+// reflect.Uint8, reflect.Uint16, reflect.Uint32 or reflect.Uint64.
+func (b *Base) DeserializeUint(i interface{}, v reflect.Value) error {
+	var (
+		value = reflect.ValueOf(i)
+		n     uint64
+	)
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if 0 > value.Int() {
+			return MismatchedValueError{i, v.Type()}
+		}
+
+		n = uint64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = value.Uint()
+	case reflect.Float32, reflect.Float64:
+		if 0 > value.Float() || value.Float() != math.Trunc(value.Float()) {
+			return MismatchedValueError{i, v.Type()}
+		}
+
+		n = uint64(value.Float())
+	default:
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	if v.OverflowUint(n) {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	v.SetUint(n)
+
+	return nil
+}
+
+// DeserializeFloat attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Float32
+// or reflect.Float64.
+func (b *Base) DeserializeFloat(i interface{}, v reflect.Value) error {
+	var (
+		value = reflect.ValueOf(i)
+		n     float64
+	)
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = value.Float()
+	default:
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	if v.OverflowFloat(n) {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	v.SetFloat(n)
+
+	return nil
+}
+
+// DeserializeString attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.String.
+func (b *Base) DeserializeString(i interface{}, v reflect.Value) error {
+	var value = reflect.ValueOf(i)
+
+	if value.Kind() != reflect.String {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	v.SetString(value.String())
+
+	return nil
+}
+
+// DeserializeInterface attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Interface.
+func (b *Base) DeserializeInterface(i interface{}, v reflect.Value) error {
+	var value = reflect.ValueOf(i)
+
+	if !value.Type().AssignableTo(v.Type()) {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	v.Set(value)
+
+	return nil
+}
+
+// DeserializePtr attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Ptr,
+// allocating the value pointed to if necessary.
+func (b *Base) DeserializePtr(i interface{}, v reflect.Value) error {
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	return b.Deserialize(i, v.Elem())
+}
+
+// DeserializeSlice attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Slice
+// or reflect.Array.
+func (b *Base) DeserializeSlice(i interface{}, v reflect.Value) error {
+	var value = reflect.ValueOf(i)
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	if v.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(v.Type(), value.Len(), value.Len()))
+	} else if v.Len() < value.Len() {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	for j := 0; j < value.Len(); j++ {
+		var element = value.Index(j)
+
+		if !element.CanInterface() {
+			return serializers.UninterfaceableValueError{Value: element}
+		}
+
+		if err := b.Deserialize(element.Interface(), v.Index(j)); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeserializeMap attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Map, converting
+// keys with DeserializeMapKey.
+func (b *Base) DeserializeMap(i interface{}, v reflect.Value) error {
+	var value = reflect.ValueOf(i)
+
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return MismatchedValueError{i, v.Type()}
+	}
+
+	var (
+		t       = v.Type()
+		mapping = reflect.MakeMapWithSize(t, value.Len())
+	)
+
+	for _, name := range value.MapKeys() {
+		var (
+			key     = reflect.New(t.Key()).Elem()
+			element = reflect.New(t.Elem()).Elem()
+			temp    = value.MapIndex(name)
+		)
+
+		if !temp.CanInterface() {
+			return serializers.UninterfaceableValueError{Value: temp}
+		} else if err := b.DeserializeMapKey(name.String(), key); nil != err {
+			return err
+		} else if err = b.Deserialize(temp.Interface(), element); nil != err {
+			return err
+		}
+
+		mapping.SetMapIndex(key, element)
+	}
+
+	v.Set(mapping)
+
+	return nil
+}
+
+// DeserializeMapKey attempts to deserialize the map key `s` into
+// the reflect.Value `v`, reversing serializers.Base's SerializeMapKey.
+// Keys implementing encoding.TextUnmarshaler take precedence over
+// string and integer keys.
+func (b *Base) DeserializeMapKey(s string, v reflect.Value) error {
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n, err = strconv.ParseInt(s, 10, v.Type().Bits())
+
+		if nil != err {
+			return MismatchedValueError{s, v.Type()}
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n, err = strconv.ParseUint(s, 10, v.Type().Bits())
+
+		if nil != err {
+			return MismatchedValueError{s, v.Type()}
+		}
+
+		v.SetUint(n)
+	default:
+		return serializers.UnsupportedMapKeyError{Type: v.Type()}
+	}
+
+	return nil
+}
+
+// DeserializeStruct attempts to deserialize a value into a
+// reflect.Value with a reflect.Kind of reflect.Struct.
+// Fields tagged for linking are resolved through the
+// document's `links` and `linked` members.
+func (b *Base) DeserializeStruct(i interface{}, v reflect.Value) error {
+	var (
+		mapping, ok            = i.(map[string]interface{})
+		t                      = v.Type()
+		identifier, identified = b.IdentifierField(t)
+	)
+
+	if !ok {
+		return MismatchedValueError{i, t}
+	}
+
+	for j := 0; j < v.NumField(); j++ {
+		var field = t.Field(j)
+
+		if 0 < len(field.PkgPath) || b.Tag(field).Ignore || serializers.IsMeta(field) {
+			continue
+		}
+
+		if identified && serializers.IsField(identifier, j) {
+			if value, ok := mapping[b.IdentifierName(t)]; ok {
+				if err := b.Deserialize(value, v.Field(j)); nil != err {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if "true" == field.Tag.Get(serializers.TranqLink) {
+			if err := b.LinkStructField(mapping, v.Field(j), field); nil != err {
+				return err
+			}
+
+			continue
+		}
+
+		if value, ok := mapping[b.AttributeName(field)]; ok {
+			if err := b.Deserialize(value, v.Field(j)); nil != err {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// LinkStructField attempts to populate a struct field from
+// the link details stored in a map[string]interface{} under
+// the JSON API reserved string `links`. Fields without link
+// details are left untouched.
+func (b *Base) LinkStructField(m map[string]interface{}, v reflect.Value, f reflect.StructField) error {
+	var (
+		links   map[string]interface{}
+		details map[string]interface{}
+		ok      bool
+	)
+
+	if links, ok = m[b.ReservedStrings.Links].(map[string]interface{}); !ok {
+		return nil
+	} else if details, ok = links[b.AttributeName(f)].(map[string]interface{}); !ok {
+		return nil
+	}
+
+	var typ, err = serializers.TypeName(f.Type)
+
+	if nil != err {
+		return err
+	}
+
+	typ = b.FormatTypeName(typ)
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Struct {
+		return b.LinkDocument(typ, details[b.ReservedStrings.ID], v)
+	} else if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return UnsupportedKindError{v.Kind(), b}
+	}
+
+	var ids, _ = details[b.ReservedStrings.IDs].([]interface{})
+
+	if v.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(v.Type(), len(ids), len(ids)))
+	} else if v.Len() < len(ids) {
+		return MismatchedValueError{ids, v.Type()}
+	}
+
+	for i := 0; i < len(ids); i++ {
+		var element = v.Index(i)
+
+		for element.Kind() == reflect.Ptr {
+			if element.IsNil() {
+				element.Set(reflect.New(element.Type().Elem()))
+			}
+
+			element = element.Elem()
+		}
+
+		if err = b.LinkDocument(typ, ids[i], element); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LinkDocument sets the identifier of the struct value `v`
+// and, if a document of type `t` with the same identifier
+// is present under the JSON API reserved string `linked`,
+// deserializes the remainder of its attributes.
+func (b *Base) LinkDocument(t string, id interface{}, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return UnsupportedKindError{v.Kind(), b}
+	}
+
+	var field, found = b.IdentifierField(v.Type())
+
+	if !found {
+		return serializers.MissingIdentifierError{Value: v, Strategy: b.IdentifierStrategy}
+	}
+
+	if err := b.Deserialize(id, v.FieldByIndex(field.Index)); nil != err {
+		return err
+	}
+
+	var (
+		key           = fmt.Sprintf("%s:%v", t, id)
+		document, ok  = b.LinkedDocument(t, id, b.IdentifierName(v.Type()))
+		_, processing = b.LinkedDocuments[key]
+	)
+
+	if !ok || processing {
+		return nil
+	}
+
+	b.LinkedDocuments[key] = struct{}{}
+	defer delete(b.LinkedDocuments, key)
+
+	return b.DeserializeStruct(document, v)
+}
+
+// LinkedDocument searches the documents stored under the
+// JSON API reserved string `linked` for a document of
+// type `t` whose attribute `attr` matches `id`.
+func (b *Base) LinkedDocument(t string, id interface{}, attr string) (map[string]interface{}, bool) {
+	var (
+		linked, _    = b.RootContext[b.ReservedStrings.Linked].(map[string]interface{})
+		documents, _ = linked[t].([]interface{})
+	)
+
+	for i := 0; i < len(documents); i++ {
+		var document, ok = documents[i].(map[string]interface{})
+
+		if ok && fmt.Sprint(document[attr]) == fmt.Sprint(id) {
+			return document, true
+		}
+	}
+
+	return nil, false
+}
+
+// IdentifierField returns the struct field of type `t`
+// identifiers are deserialized into, located by Base's
+// IdentifierStrategy, reporting false if there is none.
+func (b *Base) IdentifierField(t reflect.Type) (reflect.StructField, bool) {
+	if nil == b.IdentifierStrategy {
+		return serializers.DefaultIdentifierStrategy.IdentifierField(t)
+	}
+
+	return b.IdentifierStrategy.IdentifierField(t)
+}
+
+// IdentifierName returns the attribute name identifiers
+// of values of type `t` are read from, the JSON API
+// reserved string `id` if it has been set, otherwise the
+// attribute name of its identifier field.
+func (b *Base) IdentifierName(t reflect.Type) string {
+	if 0 < len(b.ReservedStrings.ID) {
+		return b.ReservedStrings.ID
+	} else if field, ok := b.IdentifierField(t); ok {
+		return b.AttributeName(field)
+	}
+
+	return b.FormatAttributeName(serializers.ID)
+}
+
+// Tag returns the parsed serializers.Tag of the struct field
+// `f`, read from its `tranq` struct tag, or from its `json`
+// struct tag if Base's UseJSONTags is set and no `tranq`
+// struct tag is present.
+func (b *Base) Tag(f reflect.StructField) serializers.Tag {
+	if _, ok := f.Tag.Lookup(serializers.Tranq); !ok && b.UseJSONTags {
+		return serializers.ParseJSONTag(f)
+	}
+
+	return serializers.ParseTag(f)
+}
+
+// AttributeName returns the attribute name of the struct
+// field `f`, the name provided by its Tag if present,
+// otherwise its name formatted by Base's
+// AttributeNameFormatter NamingFormatter.
+func (b *Base) AttributeName(f reflect.StructField) string {
+	if tag := b.Tag(f); 0 < len(tag.Name) {
+		return tag.Name
+	}
+
+	return b.FormatAttributeName(f.Name)
+}
+
+// FormatAttributeName allows access to Base's
+// AttributeNameFormatter NameFormatter. If no
+// AttributeNameFormatter was provided, the original
+// string is returned in place of a formatted one.
+func (b *Base) FormatAttributeName(s string) string {
+	if nil == b.AttributeNameFormatter {
+		return s
+	}
+
+	return b.AttributeNameFormatter.FormatName(s)
+}
+
+// FormatTypeName allows access to Base's
+// TypeNameFormatter NameFormatter. If no
+// TypeNameFormatter was provided, the original
+// string is returned in place of a formatted one.
+func (b *Base) FormatTypeName(s string) string {
+	if nil == b.TypeNameFormatter {
+		return s
+	}
+
+	return b.TypeNameFormatter.FormatName(s)
+}
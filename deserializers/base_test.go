@@ -0,0 +1,394 @@
+package deserializers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/deserializers"
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Person struct {
+	ID        int
+	FirstName string
+}
+
+type Comment struct {
+	ID     int
+	Body   string
+	Author Person `tranq_link:"true"`
+}
+
+type Post struct {
+	ID        int
+	Title     string
+	CreatedAt time.Time
+	Author    Person    `tranq_link:"true"`
+	Editor    *Person   `tranq_link:"true"`
+	Comments  []Comment `tranq_link:"true"`
+}
+
+var (
+	settings = configurators.Settings{
+		TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
+			return strings.ToLower(s) + "s"
+		}),
+		AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+	}
+	config = configurators.NewBase(settings)
+)
+
+func roundTrip(t *testing.T, i interface{}) map[string]interface{} {
+	var (
+		document, err = config.NewSerializer().Accept(i)
+		result        map[string]interface{}
+		encoded       []byte
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	encoded, err = json.Marshal(document)
+	assert.Nil(t, err, "received unexpected error from json.Marshal")
+	assert.Nil(t, json.Unmarshal(encoded, &result), "received unexpected error from json.Unmarshal")
+
+	return result
+}
+
+func TestInvalidTargetError(t *testing.T) {
+	var (
+		val = reflect.ValueOf(1)
+		err = deserializers.InvalidTargetError{val}
+		str = fmt.Sprintf("value `%s` must be a non-nil pointer to be deserialized into", val)
+	)
+
+	assert.Equal(t, str, err.Error(), "failed to return correct error message for InvalidTargetError")
+}
+
+func TestMissingNamespaceError(t *testing.T) {
+	var err = deserializers.MissingNamespaceError{"posts"}
+
+	assert.Equal(t, "document is missing root level namespace `posts`", err.Error(), "failed to return correct error message for MissingNamespaceError")
+}
+
+func TestMismatchedValueError(t *testing.T) {
+	var (
+		typ = reflect.TypeOf(1)
+		err = deserializers.MismatchedValueError{"string", typ}
+		str = fmt.Sprintf("cannot deserialize value `%v` of type `%T` into type `%s`", "string", "string", typ)
+	)
+
+	assert.Equal(t, str, err.Error(), "failed to return correct error message for MismatchedValueError")
+}
+
+func TestUnsupportedKindError(t *testing.T) {
+	var (
+		kind         = reflect.Chan
+		deserializer = &deserializers.Base{}
+		err          = deserializers.UnsupportedKindError{kind, deserializer}
+		str          = fmt.Sprintf("encountered a value with reflect.Kind of `%s` is unsupported by the deserializer `%T`", kind, deserializer)
+	)
+
+	assert.Equal(t, str, err.Error(), "failed to return correct error message for UnsupportedKindError")
+}
+
+func TestAcceptInvalidTarget(t *testing.T) {
+	var (
+		deserializer = config.NewDeserializer()
+		post         *Post
+	)
+
+	assert.IsType(t, deserializers.InvalidTargetError{}, deserializer.Accept(nil, Post{}), "failed to reject non-pointer target")
+	assert.IsType(t, deserializers.InvalidTargetError{}, deserializer.Accept(nil, post), "failed to reject nil pointer target")
+}
+
+func TestAcceptMissingNamespace(t *testing.T) {
+	var err = config.NewDeserializer().Accept(map[string]interface{}{}, &Post{})
+
+	assert.IsType(t, deserializers.MissingNamespaceError{}, err, "failed to return serializers.MissingNamespaceError")
+}
+
+func TestDeserializeBase(t *testing.T) {
+	var (
+		value  int8
+		result = map[string]interface{}{"int8s": float64(8)}
+		err    = config.NewDeserializer().Accept(result, &value)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, int8(8), value, "failed to deserialize int8")
+}
+
+func TestDeserializeMismatchedValue(t *testing.T) {
+	var (
+		deserializer = &deserializers.Base{}
+		value        int8
+	)
+
+	assert.IsType(t, deserializers.MismatchedValueError{}, deserializer.Deserialize("1", reflect.ValueOf(&value).Elem()), "failed to reject string for int8")
+	assert.IsType(t, deserializers.MismatchedValueError{}, deserializer.Deserialize(1.5, reflect.ValueOf(&value).Elem()), "failed to reject fractional number for int8")
+	assert.IsType(t, deserializers.MismatchedValueError{}, deserializer.Deserialize(1024, reflect.ValueOf(&value).Elem()), "failed to reject overflowing number for int8")
+}
+
+func TestDeserializePtr(t *testing.T) {
+	var (
+		deserializer = &deserializers.Base{}
+		value        *string
+		err          = deserializer.Deserialize("test", reflect.ValueOf(&value).Elem())
+	)
+
+	assert.Nil(t, err, "received unexpected error from Deserialize")
+	assert.Equal(t, "test", *value, "failed to allocate and deserialize pointer")
+}
+
+func TestDeserializeUnmarshaler(t *testing.T) {
+	var (
+		deserializer = &deserializers.Base{}
+		created      = time.Date(2014, time.October, 1, 12, 0, 0, 0, time.UTC)
+		value        time.Time
+		address      net.IP
+	)
+
+	assert.Nil(t, deserializer.Deserialize("2014-10-01T12:00:00Z", reflect.ValueOf(&value).Elem()), "received unexpected error from Deserialize")
+	assert.Equal(t, created, value, "failed to deserialize time.Time with json.Unmarshaler")
+	assert.Nil(t, deserializer.Deserialize(created, reflect.ValueOf(&value).Elem()), "received unexpected error from Deserialize")
+	assert.Equal(t, created, value, "failed to assign time.Time directly")
+	assert.Nil(t, deserializer.Deserialize("127.0.0.1", reflect.ValueOf(&address).Elem()), "received unexpected error from Deserialize")
+	assert.Equal(t, "127.0.0.1", address.String(), "failed to deserialize net.IP with encoding.TextUnmarshaler")
+}
+
+func TestDeserializeStruct(t *testing.T) {
+	var (
+		post = Post{
+			ID:        1,
+			Title:     "Lorem ipsum",
+			CreatedAt: time.Date(2014, time.October, 1, 12, 0, 0, 0, time.UTC),
+			Author:    Person{1, "Jon"},
+			Editor:    &Person{2, "Jane"},
+			Comments: []Comment{
+				Comment{1, "First", Person{2, "Jane"}},
+				Comment{2, "Second", Person{1, "Jon"}},
+			},
+		}
+		result Post
+		err    = config.NewDeserializer().Accept(roundTrip(t, post), &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, post, result, "failed to deserialize document into original struct")
+}
+
+func TestAcceptFork(t *testing.T) {
+	var (
+		deserializer = config.NewDeserializer().(*deserializers.Base)
+		post         = Post{ID: 1, Title: "First", Author: Person{1, "Jon"}, Comments: []Comment{}}
+		result       Post
+		err          = deserializer.Accept(roundTrip(t, post), &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, post, result, "failed to deserialize document with a Fork")
+	assert.Nil(t, deserializer.RootContext, "modified RootContext of forked deserializer")
+	assert.Nil(t, deserializer.LinkedDocuments, "modified LinkedDocuments of forked deserializer")
+}
+
+func TestDeserializeSlice(t *testing.T) {
+	var (
+		posts = []Post{
+			Post{ID: 1, Title: "First", Author: Person{1, "Jon"}, Comments: []Comment{}},
+			Post{ID: 2, Title: "Second", Author: Person{2, "Jane"}, Comments: []Comment{}},
+		}
+		result []Post
+		err    = config.NewDeserializer().Accept(roundTrip(t, posts), &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, posts, result, "failed to deserialize document into original slice")
+}
+
+type Coordinate struct {
+	X, Y int
+}
+
+func (c Coordinate) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d,%d", c.X, c.Y)), nil
+}
+
+func (c *Coordinate) UnmarshalText(text []byte) error {
+	var _, err = fmt.Sscanf(string(text), "%d,%d", &c.X, &c.Y)
+	return err
+}
+
+type Board struct {
+	ID     int
+	Labels map[string]string
+	Scores map[int]float64
+	Counts map[uint8][]int
+	Pieces map[Coordinate]string
+	Extra  map[string]interface{}
+}
+
+func TestDeserializeMap(t *testing.T) {
+	var (
+		board = Board{
+			ID:     1,
+			Labels: map[string]string{"name": "chess"},
+			Scores: map[int]float64{-1: 0.5, 2: 1},
+			Counts: map[uint8][]int{8: []int{1, 2}},
+			Pieces: map[Coordinate]string{Coordinate{1, 2}: "king"},
+			Extra:  map[string]interface{}{"nested": map[string]interface{}{"ok": true}},
+		}
+		result Board
+		err    = config.NewDeserializer().Accept(roundTrip(t, board), &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, board, result, "failed to deserialize maps into original struct")
+}
+
+func TestDeserializeMapKey(t *testing.T) {
+	var (
+		deserializer = &deserializers.Base{}
+		small        map[int8]int
+		float        map[float64]int
+	)
+
+	assert.IsType(t, deserializers.MismatchedValueError{}, deserializer.Deserialize(map[string]interface{}{"1024": 1}, reflect.ValueOf(&small).Elem()), "failed to reject overflowing integer key")
+	assert.IsType(t, serializers.UnsupportedMapKeyError{}, deserializer.Deserialize(map[string]interface{}{"1.5": 1}, reflect.ValueOf(&float).Elem()), "failed to reject unsupported key type")
+	assert.IsType(t, deserializers.MismatchedValueError{}, deserializer.Deserialize([]interface{}{}, reflect.ValueOf(&small).Elem()), "failed to reject non-object value for map")
+}
+
+func TestDeserializeStructTags(t *testing.T) {
+	type Tagged struct {
+		ID     int
+		Title  string `tranq:"heading"`
+		Secret string `tranq:"-"`
+		Author Person `tranq:"writer" tranq_link:"true"`
+	}
+
+	var (
+		document = map[string]interface{}{
+			"taggeds": map[string]interface{}{
+				"id":      1,
+				"heading": "Lorem",
+				"secret":  "secret",
+				"links": map[string]interface{}{
+					"writer": map[string]interface{}{"id": 3, "type": "persons"},
+				},
+			},
+		}
+		result Tagged
+		err    = config.NewDeserializer().Accept(document, &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, Tagged{ID: 1, Title: "Lorem", Author: Person{ID: 3}}, result, "failed to apply `tranq` struct tags")
+}
+
+func TestDeserializeStructJSONTags(t *testing.T) {
+	type Tagged struct {
+		ID        int    `json:"id"`
+		FirstName string `json:"first_name,omitempty"`
+		Password  string `json:"-"`
+	}
+
+	var (
+		document = map[string]interface{}{
+			"Tagged": map[string]interface{}{"id": 1, "first_name": "Jon", "Password": "secret"},
+		}
+		deserializer = &deserializers.Base{UseJSONTags: true}
+		result       Tagged
+		err          = deserializer.Accept(document, &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, Tagged{ID: 1, FirstName: "Jon"}, result, "failed to read `json` struct tags")
+}
+
+func TestLinkStructFieldWithoutLinked(t *testing.T) {
+	var (
+		document = map[string]interface{}{
+			"posts": map[string]interface{}{
+				"id": 1,
+				"links": map[string]interface{}{
+					"author": map[string]interface{}{"id": 3, "type": "persons"},
+				},
+			},
+		}
+		result Post
+		err    = config.NewDeserializer().Accept(document, &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, Person{ID: 3}, result.Author, "failed to link resource by identifier")
+}
+
+func TestLinkDocumentMissingIdentifier(t *testing.T) {
+	type Tag struct {
+		Name string
+	}
+
+	var (
+		deserializer = &deserializers.Base{}
+		tag          Tag
+		err          = deserializer.LinkDocument("tags", 1, reflect.ValueOf(&tag).Elem())
+	)
+
+	assert.IsType(t, serializers.MissingIdentifierError{}, err, "failed to return serializers.MissingIdentifierError")
+}
+
+func TestFormatAttributeName(t *testing.T) {
+	var deserializer = &deserializers.Base{
+		AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+	}
+
+	assert.Equal(t, "test", deserializer.FormatAttributeName("Test"), "failed to format string with supplied AttributeNameFormatter")
+	deserializer.AttributeNameFormatter = nil
+	assert.Equal(t, "Test", deserializer.FormatAttributeName("Test"), "failed to return default value when no AttributeNameFormatter supplied")
+}
+
+func TestFormatTypeName(t *testing.T) {
+	var deserializer = &deserializers.Base{
+		TypeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+	}
+
+	assert.Equal(t, "test", deserializer.FormatTypeName("Test"), "failed to format string with supplied TypeNameFormatter")
+	deserializer.TypeNameFormatter = nil
+	assert.Equal(t, "Test", deserializer.FormatTypeName("Test"), "failed to return default value when no TypeNameFormatter supplied")
+}
+
+func TestDeserializeIdentifierStrategy(t *testing.T) {
+	type Author struct {
+		Key  string `tranq:"id"`
+		Name string
+	}
+
+	type Book struct {
+		Key    string `tranq:"id"`
+		Title  string
+		Author Author `tranq_link:"true"`
+	}
+
+	var (
+		tagged = configurators.NewBase(configurators.Settings{
+			TypeNameFormatter:      settings.TypeNameFormatter,
+			AttributeNameFormatter: settings.AttributeNameFormatter,
+			IdentifierStrategy:     serializers.TagIdentifier(serializers.Tranq),
+		})
+		book          = Book{"b1", "Lorem", Author{"a1", "Jon"}}
+		document, err = tagged.NewSerializer().Accept(book)
+		result        Book
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Nil(t, tagged.NewDeserializer().Accept(document, &result), "received unexpected error from Accept")
+	assert.Equal(t, book, result, "failed to deserialize identifiers located by IdentifierStrategy")
+}
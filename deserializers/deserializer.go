@@ -0,0 +1,11 @@
+package deserializers
+
+// Deserializer interface provides the ability
+// to deserialize documents complying with the
+// standards set by JSON API back into go objects.
+type Deserializer interface {
+	// Accept provides the Deserializer with the
+	// JSON API document and a pointer to the go
+	// object it should populate.
+	Accept(m map[string]interface{}, i interface{}) error
+}
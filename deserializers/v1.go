@@ -0,0 +1,297 @@
+package deserializers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+// V1 is a type implementing the Deserializer interface,
+// reading documents in the format specified by JSON API 1.0
+// and produced by serializers.V1.
+type V1 struct {
+	Base
+}
+
+// Accept implements the `Accept` method required
+// by the Deserializer interface, deserializing `m`
+// into `i` with a Fork of V1.
+func (v *V1) Accept(m map[string]interface{}, i interface{}) error {
+	return v.Fork().accept(m, i)
+}
+
+// Fork returns a copy of V1 sharing its settings with
+// no document being deserialized, as Base's Fork does.
+func (v *V1) Fork() *V1 {
+	var fork = *v
+	fork.RootContext = nil
+	fork.LinkedDocuments = nil
+
+	return &fork
+}
+
+// accept deserializes `m` into `i` as Accept does,
+// modifying V1's RootContext and LinkedDocuments.
+func (v *V1) accept(m map[string]interface{}, i interface{}) (err error) {
+	var (
+		value    = reflect.ValueOf(i)
+		document interface{}
+		ok       bool
+	)
+
+	defer func() {
+		if temp := recover(); nil != temp {
+			if _, ok := temp.(error); ok {
+				err = temp.(error)
+			} else {
+				err = fmt.Errorf("%s", temp)
+			}
+		}
+	}()
+
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return InvalidTargetError{value}
+	}
+
+	if document, ok = m[v.ReservedStrings.Data]; !ok {
+		return MissingNamespaceError{v.ReservedStrings.Data}
+	}
+
+	v.RootContext = m
+	v.LinkedDocuments = make(map[string]struct{})
+
+	return v.DeserializeData(document, value.Elem())
+}
+
+// DeserializeData deserializes the primary data of a document,
+// either a single resource, a collection of resources or nil.
+func (v *V1) DeserializeData(i interface{}, r reflect.Value) error {
+	if nil == i {
+		r.Set(reflect.Zero(r.Type()))
+		return nil
+	}
+
+	for r.Kind() == reflect.Ptr {
+		if r.IsNil() {
+			r.Set(reflect.New(r.Type().Elem()))
+		}
+
+		r = r.Elem()
+	}
+
+	if r.Kind() == reflect.Struct {
+		return v.DeserializeResource(i, r)
+	} else if r.Kind() != reflect.Slice {
+		return UnsupportedKindError{r.Kind(), v}
+	}
+
+	var collection, ok = i.([]interface{})
+
+	if !ok {
+		return MismatchedValueError{i, r.Type()}
+	}
+
+	r.Set(reflect.MakeSlice(r.Type(), len(collection), len(collection)))
+
+	for j := 0; j < len(collection); j++ {
+		if err := v.DeserializeData(collection[j], r.Index(j)); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeserializeResource attempts to deserialize a JSON API resource
+// object into a reflect.Value with a reflect.Kind of reflect.Struct.
+func (v *V1) DeserializeResource(i interface{}, r reflect.Value) error {
+	var (
+		resource, ok     = i.(map[string]interface{})
+		attributes, _    = resource[v.ReservedStrings.Attributes].(map[string]interface{})
+		relationships, _ = resource[v.ReservedStrings.Relationships].(map[string]interface{})
+		t                = r.Type()
+	)
+
+	if !ok {
+		return MismatchedValueError{i, t}
+	}
+
+	if id, ok := resource[v.ReservedStrings.ID]; ok {
+		if err := v.DeserializeIdentifier(id, r); nil != err {
+			return err
+		}
+	}
+
+	var identifier, identified = v.IdentifierField(t)
+
+	for j := 0; j < r.NumField(); j++ {
+		var field = t.Field(j)
+
+		if 0 < len(field.PkgPath) || v.Tag(field).Ignore || serializers.IsMeta(field) || (identified && serializers.IsField(identifier, j)) {
+			continue
+		}
+
+		var attr = v.AttributeName(field)
+
+		if "true" == field.Tag.Get(serializers.TranqLink) {
+			var relationship, _ = relationships[attr].(map[string]interface{})
+
+			if err := v.RelateStructField(relationship, r.Field(j), field); nil != err {
+				return err
+			}
+		} else if value, ok := attributes[attr]; ok {
+			if err := v.Deserialize(value, r.Field(j)); nil != err {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeserializeIdentifier sets the identifier field of the struct
+// value `r`. JSON API 1.0 represents identifiers as strings, which
+// are decoded as JSON when the identifier field is numeric.
+func (v *V1) DeserializeIdentifier(id interface{}, r reflect.Value) error {
+	var identifier, found = v.IdentifierField(r.Type())
+
+	if !found {
+		return serializers.MissingIdentifierError{Value: r, Strategy: v.IdentifierStrategy}
+	}
+
+	var (
+		field   = r.FieldByIndex(identifier.Index)
+		str, ok = id.(string)
+	)
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if ok {
+			if err := json.Unmarshal([]byte(str), field.Addr().Interface()); nil != err {
+				return MismatchedValueError{id, field.Type()}
+			}
+
+			return nil
+		}
+	}
+
+	return v.Deserialize(id, field)
+}
+
+// RelateStructField attempts to populate a struct field from a
+// JSON API relationship object. Fields without a relationship
+// object, or whose relationship has no resource linkage, are
+// left untouched.
+func (v *V1) RelateStructField(m map[string]interface{}, r reflect.Value, f reflect.StructField) error {
+	var data, ok = m[v.ReservedStrings.Data]
+
+	if !ok {
+		return nil
+	} else if nil == data {
+		r.Set(reflect.Zero(r.Type()))
+		return nil
+	}
+
+	for r.Kind() == reflect.Ptr {
+		if r.IsNil() {
+			r.Set(reflect.New(r.Type().Elem()))
+		}
+
+		r = r.Elem()
+	}
+
+	if r.Kind() == reflect.Struct {
+		return v.LinkDocument(data, r)
+	} else if r.Kind() != reflect.Slice && r.Kind() != reflect.Array {
+		return UnsupportedKindError{r.Kind(), v}
+	}
+
+	var identifiers, _ = data.([]interface{})
+
+	if r.Kind() == reflect.Slice {
+		r.Set(reflect.MakeSlice(r.Type(), len(identifiers), len(identifiers)))
+	} else if r.Len() < len(identifiers) {
+		return MismatchedValueError{identifiers, r.Type()}
+	}
+
+	for i := 0; i < len(identifiers); i++ {
+		var element = r.Index(i)
+
+		for element.Kind() == reflect.Ptr {
+			if element.IsNil() {
+				element.Set(reflect.New(element.Type().Elem()))
+			}
+
+			element = element.Elem()
+		}
+
+		if err := v.LinkDocument(identifiers[i], element); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LinkDocument sets the identifier of the struct value `r` from
+// a JSON API resource identifier object and, if a resource with
+// the same type and identifier is present under the JSON API
+// reserved string `included`, deserializes it into `r`.
+func (v *V1) LinkDocument(i interface{}, r reflect.Value) error {
+	if r.Kind() != reflect.Struct {
+		return UnsupportedKindError{r.Kind(), v}
+	}
+
+	var identifier, ok = i.(map[string]interface{})
+
+	if !ok {
+		return MismatchedValueError{i, r.Type()}
+	}
+
+	var (
+		typ, _ = identifier[v.ReservedStrings.Type].(string)
+		id     = identifier[v.ReservedStrings.ID]
+	)
+
+	if err := v.DeserializeIdentifier(id, r); nil != err {
+		return err
+	}
+
+	var (
+		key             = fmt.Sprintf("%s:%v", typ, id)
+		resource, found = v.IncludedDocument(typ, id)
+		_, processing   = v.LinkedDocuments[key]
+	)
+
+	if !found || processing {
+		return nil
+	}
+
+	v.LinkedDocuments[key] = struct{}{}
+	defer delete(v.LinkedDocuments, key)
+
+	return v.DeserializeResource(resource, r)
+}
+
+// IncludedDocument searches the resources stored under the
+// JSON API reserved string `included` for a resource of
+// type `t` identified by `id`.
+func (v *V1) IncludedDocument(t string, id interface{}) (map[string]interface{}, bool) {
+	var included, _ = v.RootContext[v.ReservedStrings.Included].([]interface{})
+
+	for i := 0; i < len(included); i++ {
+		var resource, ok = included[i].(map[string]interface{})
+
+		if ok && resource[v.ReservedStrings.Type] == t && fmt.Sprint(resource[v.ReservedStrings.ID]) == fmt.Sprint(id) {
+			return resource, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,121 @@
+package deserializers_test
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/deserializers"
+	"github.com/stretchr/testify/assert"
+)
+
+var v1 = configurators.NewV1(settings)
+
+func roundTripV1(t *testing.T, i interface{}) map[string]interface{} {
+	var (
+		document, err = v1.NewSerializer().Accept(i)
+		result        map[string]interface{}
+		encoded       []byte
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	encoded, err = json.Marshal(document)
+	assert.Nil(t, err, "received unexpected error from json.Marshal")
+	assert.Nil(t, json.Unmarshal(encoded, &result), "received unexpected error from json.Unmarshal")
+
+	return result
+}
+
+func TestV1DeserializeResource(t *testing.T) {
+	var (
+		post = Post{
+			ID:     1,
+			Title:  "Lorem ipsum",
+			Author: Person{1, "Jon"},
+			Editor: &Person{2, "Jane"},
+			Comments: []Comment{
+				Comment{1, "First", Person{2, "Jane"}},
+				Comment{2, "Second", Person{1, "Jon"}},
+			},
+		}
+		result Post
+		err    = v1.NewDeserializer().Accept(roundTripV1(t, post), &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, post, result, "failed to deserialize document into original struct")
+}
+
+func TestV1DeserializeMap(t *testing.T) {
+	var (
+		board = Board{
+			ID:     1,
+			Labels: map[string]string{"name": "chess"},
+			Scores: map[int]float64{-1: 0.5},
+			Pieces: map[Coordinate]string{Coordinate{1, 2}: "king"},
+		}
+		result Board
+		err    = v1.NewDeserializer().Accept(roundTripV1(t, board), &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, board, result, "failed to deserialize maps into original struct")
+}
+
+func TestV1DeserializeCollection(t *testing.T) {
+	var (
+		posts = []Post{
+			Post{ID: 1, Title: "First", Author: Person{1, "Jon"}, Comments: []Comment{}},
+			Post{ID: 2, Title: "Second", Author: Person{2, "Jane"}, Comments: []Comment{}},
+		}
+		result []Post
+		err    = v1.NewDeserializer().Accept(roundTripV1(t, posts), &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, posts, result, "failed to deserialize document into original slice")
+}
+
+func TestV1DeserializeMissingData(t *testing.T) {
+	var err = v1.NewDeserializer().Accept(map[string]interface{}{}, &Post{})
+
+	assert.IsType(t, deserializers.MissingNamespaceError{}, err, "failed to return deserializers.MissingNamespaceError")
+}
+
+func TestV1DeserializeIdentifier(t *testing.T) {
+	var (
+		document = map[string]interface{}{
+			"data": map[string]interface{}{"type": "posts", "id": "abc"},
+		}
+		result Post
+		err    = v1.NewDeserializer().Accept(document, &result)
+	)
+
+	assert.IsType(t, deserializers.MismatchedValueError{}, err, "failed to reject non-numeric identifier for numeric field")
+}
+
+func TestV1DeserializeMeta(t *testing.T) {
+	type Draft struct {
+		ID       int
+		Title    string
+		Revision int `tranq_meta:"true"`
+	}
+
+	var (
+		document = map[string]interface{}{
+			"data": map[string]interface{}{
+				"type":       "drafts",
+				"id":         "1",
+				"attributes": map[string]interface{}{"title": "Lorem", "revision": float64(3)},
+			},
+		}
+		result Draft
+		err    = v1.NewDeserializer().Accept(document, &result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, Draft{ID: 1, Title: "Lorem"}, result, "failed to skip meta fields")
+}
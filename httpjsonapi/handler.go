@@ -0,0 +1,85 @@
+package httpjsonapi
+
+import (
+	"net/http"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+// Handler provides an interface for responding to HTTP
+// requests with the status code and go object to be
+// rendered as a JSON API document.
+type Handler interface {
+	// ServeJSONAPI returns the status code and go object
+	// to render in response to the request `r`, or an
+	// error to render as a JSON API error document.
+	ServeJSONAPI(r *http.Request) (int, interface{}, error)
+}
+
+// HandlerFunc is an adapter allowing the use of ordinary
+// functions as implementations of the Handler interface.
+type HandlerFunc func(r *http.Request) (int, interface{}, error)
+
+// ServeJSONAPI implements the Handler interface
+// for the HandlerFunc type.
+func (f HandlerFunc) ServeJSONAPI(r *http.Request) (int, interface{}, error) {
+	return f(r)
+}
+
+// Response is a go object returned by a Handler along with
+// the serializers.Option values it is rendered with by Wrap,
+// i.e. the Options of the request's Query.
+type Response struct {
+	Value   interface{}
+	Options []serializers.Option
+}
+
+// Wrap returns an http.Handler negotiating each request,
+// responding with http.StatusUnsupportedMediaType or
+// http.StatusNotAcceptable as the JSON API specification
+// requires, before rendering the result of the Handler `h`.
+// Errors returned by `h` are rendered as JSON API error
+// documents with the status code returned alongside them,
+// or the status code determined by ErrorStatus if it is
+// not an error status code. Values of the Response type, or
+// pointers to it, are rendered with their Options; a nil
+// *Response is answered with http.StatusNoContent.
+func (r *Renderer) Wrap(h Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if status, err := Negotiate(req); nil != err {
+			r.RenderError(w, req, status, err)
+			return
+		}
+
+		var status, value, err = h.ServeJSONAPI(req)
+
+		if nil == err {
+			switch response := value.(type) {
+			case Response:
+				r.render(w, req, status, response.Value, response.Options...)
+			case *Response:
+				if nil == response {
+					r.render(w, req, http.StatusNoContent, nil)
+				} else {
+					r.render(w, req, status, response.Value, response.Options...)
+				}
+			default:
+				r.render(w, req, status, value)
+			}
+
+			return
+		} else if http.StatusBadRequest > status {
+			status = r.ErrorStatus(err)
+		}
+
+		r.RenderError(w, req, status, err)
+	})
+}
+
+// Wrap returns an http.Handler rendering the results of
+// the Handler `h` using the DefaultRenderer.
+func Wrap(h Handler) http.Handler {
+	return DefaultRenderer.Wrap(h)
+}
@@ -0,0 +1,149 @@
+package httpjsonapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/httpjsonapi"
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerFuncImplementation(t *testing.T) {
+	var f = httpjsonapi.HandlerFunc(func(r *http.Request) (int, interface{}, error) { return 0, nil, nil })
+	assert.Implements(t, (*httpjsonapi.Handler)(nil), f, "HandlerFunc failed to implement Handler interface")
+}
+
+func TestWrap(t *testing.T) {
+	var (
+		w       = httptest.NewRecorder()
+		r       = httptest.NewRequest("GET", "/posts/1", nil)
+		handler = renderer.Wrap(httpjsonapi.HandlerFunc(func(r *http.Request) (int, interface{}, error) {
+			return http.StatusCreated, Post{1, "Lorem"}, nil
+		}))
+	)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusCreated, w.Code, "failed to write status code")
+	assert.Equal(t, httpjsonapi.MediaType, w.Header().Get("Content-Type"), "failed to set Content-Type")
+	assert.JSONEq(t, `{"data": {"type": "posts", "id": "1", "attributes": {"title": "Lorem"}}}`, w.Body.String(), "failed to write JSON API document")
+}
+
+func TestWrapResponse(t *testing.T) {
+	var (
+		w       = httptest.NewRecorder()
+		r       = httptest.NewRequest("GET", "/posts/1?fields[posts]=", nil)
+		handler = renderer.Wrap(httpjsonapi.HandlerFunc(func(r *http.Request) (int, interface{}, error) {
+			var query, err = httpjsonapi.ParseQuery(r)
+
+			if nil != err {
+				return http.StatusBadRequest, nil, err
+			}
+
+			return http.StatusOK, httpjsonapi.Response{
+				Value:   Post{1, "Lorem"},
+				Options: append(query.Options(), serializers.Meta(map[string]interface{}{"request": "abc"})),
+			}, nil
+		}))
+	)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code, "failed to write status code")
+	assert.JSONEq(t, `{"data": {"type": "posts", "id": "1"}, "meta": {"request": "abc"}}`, w.Body.String(), "failed to render Response with its Options")
+}
+
+func TestWrapResponsePointer(t *testing.T) {
+	var (
+		w        = httptest.NewRecorder()
+		r        = httptest.NewRequest("GET", "/posts/1", nil)
+		response *httpjsonapi.Response
+		handler  = renderer.Wrap(httpjsonapi.HandlerFunc(func(r *http.Request) (int, interface{}, error) {
+			return http.StatusOK, response, nil
+		}))
+	)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNoContent, w.Code, "failed to answer nil *Response with no content")
+	assert.Empty(t, w.Body.String(), "wrote body for nil *Response")
+
+	w = httptest.NewRecorder()
+	response = &httpjsonapi.Response{
+		Value:   Post{1, "Lorem"},
+		Options: []serializers.Option{serializers.Meta(map[string]interface{}{"request": "abc"})},
+	}
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code, "failed to write status code")
+	assert.JSONEq(t, `{"data": {"type": "posts", "id": "1", "attributes": {"title": "Lorem"}}, "meta": {"request": "abc"}}`, w.Body.String(), "failed to render *Response with its Options")
+}
+
+func TestWrapUnsupportedMediaType(t *testing.T) {
+	var (
+		called  bool
+		w       = httptest.NewRecorder()
+		r       = httptest.NewRequest("POST", "/posts", nil)
+		handler = renderer.Wrap(httpjsonapi.HandlerFunc(func(r *http.Request) (int, interface{}, error) {
+			called = true
+			return http.StatusCreated, Post{1, "Lorem"}, nil
+		}))
+	)
+
+	r.Header.Set("Content-Type", httpjsonapi.MediaType+"; charset=utf-8")
+	handler.ServeHTTP(w, r)
+
+	assert.False(t, called, "called Handler for unsupported media type")
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code, "failed to write status code")
+	assert.Contains(t, w.Body.String(), `"status":"415"`, "failed to write JSON API error document")
+}
+
+func TestWrapError(t *testing.T) {
+	var tests = []struct {
+		Status   int
+		Expected int
+	}{
+		{http.StatusNotFound, http.StatusNotFound},
+		{http.StatusOK, http.StatusInternalServerError},
+		{0, http.StatusInternalServerError},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		var (
+			status  = tests[i].Status
+			w       = httptest.NewRecorder()
+			r       = httptest.NewRequest("GET", "/posts/1", nil)
+			handler = httpjsonapi.Wrap(httpjsonapi.HandlerFunc(func(r *http.Request) (int, interface{}, error) {
+				return status, nil, errors.New("failed")
+			}))
+		)
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, tests[i].Expected, w.Code, "failed to write status code for error returned with status %d", status)
+		assert.Contains(t, w.Body.String(), `"title":"`+http.StatusText(tests[i].Expected)+`"`, "failed to write JSON API error document")
+		assert.NotContains(t, w.Body.String(), "failed", "wrote message of error to client")
+	}
+}
+
+func TestWrapErrorStatus(t *testing.T) {
+	var (
+		w       = httptest.NewRecorder()
+		r       = httptest.NewRequest("GET", "/posts?page[size]=0", nil)
+		handler = renderer.Wrap(httpjsonapi.HandlerFunc(func(r *http.Request) (int, interface{}, error) {
+			var _, err = httpjsonapi.ParseQuery(r)
+			return 0, nil, err
+		}))
+	)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "failed to write status code of error objects")
+	assert.Contains(t, w.Body.String(), `"parameter":"page[size]"`, "failed to write JSON API error document")
+}
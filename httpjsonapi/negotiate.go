@@ -0,0 +1,109 @@
+package httpjsonapi
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+const (
+	// MediaType is the media type of JSON API documents.
+	MediaType = "application/vnd.api+json"
+	// Quality is the accept-param weighting media ranges
+	// within an Accept header, not considered a media
+	// type parameter during negotiation.
+	Quality = "q"
+)
+
+// UnsupportedMediaTypeError occurs when a request specifies
+// the JSON API media type as its Content-Type with media
+// type parameters.
+type UnsupportedMediaTypeError struct {
+	ContentType string
+}
+
+// Error implements the `error` interface for the
+// UnsupportedMediaTypeError type.
+func (u UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("content type `%s` must not contain media type parameters", u.ContentType)
+}
+
+// ErrorObjects implements the serializers.ErrorObjects
+// interface for the UnsupportedMediaTypeError type.
+func (u UnsupportedMediaTypeError) ErrorObjects() []serializers.Error {
+	return []serializers.Error{serializers.Error{
+		Status: strconv.Itoa(http.StatusUnsupportedMediaType),
+		Title:  http.StatusText(http.StatusUnsupportedMediaType),
+		Detail: u.Error(),
+	}}
+}
+
+// NotAcceptableError occurs when a request's Accept header
+// contains the JSON API media type, but every instance of
+// it is modified with media type parameters.
+type NotAcceptableError struct {
+	Accept string
+}
+
+// Error implements the `error` interface for the
+// NotAcceptableError type.
+func (n NotAcceptableError) Error() string {
+	return fmt.Sprintf("accept header `%s` contains only JSON API media types with media type parameters", n.Accept)
+}
+
+// ErrorObjects implements the serializers.ErrorObjects
+// interface for the NotAcceptableError type.
+func (n NotAcceptableError) ErrorObjects() []serializers.Error {
+	return []serializers.Error{serializers.Error{
+		Status: strconv.Itoa(http.StatusNotAcceptable),
+		Title:  http.StatusText(http.StatusNotAcceptable),
+		Detail: n.Error(),
+	}}
+}
+
+// Negotiate checks the Content-Type and Accept headers of the
+// request `r` against the rules of the JSON API specification,
+// returning http.StatusUnsupportedMediaType or
+// http.StatusNotAcceptable along with an error describing
+// the violation, otherwise http.StatusOK and nil.
+func Negotiate(r *http.Request) (int, error) {
+	if contentType := r.Header.Get("Content-Type"); 0 < len(contentType) {
+		var typ, params, err = mime.ParseMediaType(contentType)
+
+		if nil == err && MediaType == typ && 0 < len(params) {
+			return http.StatusUnsupportedMediaType, UnsupportedMediaTypeError{contentType}
+		}
+	}
+
+	var (
+		accept     = strings.Join(r.Header.Values("Accept"), ",")
+		ranges     = strings.Split(accept, ",")
+		found      bool
+		acceptable bool
+	)
+
+	for i := 0; i < len(ranges); i++ {
+		var typ, params, err = mime.ParseMediaType(ranges[i])
+
+		if nil != err || MediaType != typ {
+			continue
+		}
+
+		delete(params, Quality)
+
+		found = true
+		acceptable = acceptable || 0 == len(params)
+	}
+
+	if found && !acceptable {
+		return http.StatusNotAcceptable, NotAcceptableError{accept}
+	}
+
+	return http.StatusOK, nil
+}
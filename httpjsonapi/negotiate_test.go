@@ -0,0 +1,68 @@
+package httpjsonapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/httpjsonapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsupportedMediaTypeError(t *testing.T) {
+	var err = httpjsonapi.UnsupportedMediaTypeError{"application/vnd.api+json; charset=utf-8"}
+
+	assert.Equal(t, "content type `application/vnd.api+json; charset=utf-8` must not contain media type parameters", err.Error(), "failed to return correct error message for UnsupportedMediaTypeError")
+}
+
+func TestNotAcceptableError(t *testing.T) {
+	var err = httpjsonapi.NotAcceptableError{"application/vnd.api+json; version=1"}
+
+	assert.Equal(t, "accept header `application/vnd.api+json; version=1` contains only JSON API media types with media type parameters", err.Error(), "failed to return correct error message for NotAcceptableError")
+}
+
+func TestNegotiate(t *testing.T) {
+	var tests = []struct {
+		ContentType string
+		Accept      string
+		Status      int
+	}{
+		{"", "", http.StatusOK},
+		{httpjsonapi.MediaType, httpjsonapi.MediaType, http.StatusOK},
+		{"application/json; charset=utf-8", "application/json", http.StatusOK},
+		{httpjsonapi.MediaType + "; charset=utf-8", "", http.StatusUnsupportedMediaType},
+		{"", httpjsonapi.MediaType + "; version=1", http.StatusNotAcceptable},
+		{"", httpjsonapi.MediaType + "; version=1, " + httpjsonapi.MediaType, http.StatusOK},
+		{"", httpjsonapi.MediaType + "; q=0.9, text/html", http.StatusOK},
+		{"", httpjsonapi.MediaType + "; version=1, */*", http.StatusNotAcceptable},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		var r = httptest.NewRequest("GET", "/posts", nil)
+
+		if 0 < len(tests[i].ContentType) {
+			r.Header.Set("Content-Type", tests[i].ContentType)
+		}
+
+		if 0 < len(tests[i].Accept) {
+			r.Header.Set("Accept", tests[i].Accept)
+		}
+
+		var status, err = httpjsonapi.Negotiate(r)
+
+		assert.Equal(t, tests[i].Status, status, "failed to negotiate Content-Type `%s` and Accept `%s`", tests[i].ContentType, tests[i].Accept)
+		assert.Equal(t, http.StatusOK == tests[i].Status, nil == err, "failed to return error for Content-Type `%s` and Accept `%s`", tests[i].ContentType, tests[i].Accept)
+	}
+}
+
+func TestNegotiationErrorObjects(t *testing.T) {
+	var (
+		unsupported = httpjsonapi.UnsupportedMediaTypeError{"application/vnd.api+json; charset=utf-8"}.ErrorObjects()
+		acceptable  = httpjsonapi.NotAcceptableError{"application/vnd.api+json; version=1"}.ErrorObjects()
+	)
+
+	assert.Equal(t, "415", unsupported[0].Status, "failed to describe UnsupportedMediaTypeError")
+	assert.Equal(t, "406", acceptable[0].Status, "failed to describe NotAcceptableError")
+}
@@ -0,0 +1,385 @@
+package httpjsonapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+const (
+	// IncludeParameter is the query parameter containing
+	// the relationship paths to sideload.
+	IncludeParameter = "include"
+	// FieldsParameter is the query parameter family
+	// containing sparse fieldsets, i.e. `fields[posts]`.
+	FieldsParameter = "fields"
+	// SortParameter is the query parameter containing
+	// the sort fields of a collection.
+	SortParameter = "sort"
+	// PageParameter is the query parameter family
+	// containing pagination settings, i.e. `page[size]`.
+	PageParameter = serializers.PageParameter
+	// FilterParameter is the query parameter family
+	// containing filters, i.e. `filter[author]`.
+	FilterParameter = "filter"
+)
+
+const (
+	// PageNumber is the member of the page parameter
+	// family containing a page number.
+	PageNumber = serializers.PageNumber
+	// PageSize is the member of the page parameter
+	// family containing a page size.
+	PageSize = serializers.PageSize
+	// PageOffset is the member of the page parameter
+	// family containing an offset.
+	PageOffset = serializers.PageOffset
+	// PageLimit is the member of the page parameter
+	// family containing a limit.
+	PageLimit = serializers.PageLimit
+	// PageCursor is the member of the page parameter
+	// family containing a cursor.
+	PageCursor = serializers.PageCursor
+)
+
+// InvalidParameterError occurs when a JSON API query
+// parameter contains an invalid value.
+type InvalidParameterError struct {
+	Parameter string
+	Value     string
+	Reason    string
+}
+
+// Error implements the `error` interface for the
+// InvalidParameterError type.
+func (i InvalidParameterError) Error() string {
+	return fmt.Sprintf("query parameter `%s` with value `%s` is invalid, %s", i.Parameter, i.Value, i.Reason)
+}
+
+// ErrorObjects implements the serializers.ErrorObjects
+// interface for the InvalidParameterError type.
+func (i InvalidParameterError) ErrorObjects() []serializers.Error {
+	return []serializers.Error{serializers.Error{
+		Status: strconv.Itoa(http.StatusBadRequest),
+		Title:  "Invalid Query Parameter",
+		Detail: i.Error(),
+		Source: serializers.ErrorSource{Parameter: i.Parameter},
+	}}
+}
+
+// InvalidQueryError occurs when one or more JSON API
+// query parameters of a request are invalid.
+type InvalidQueryError struct {
+	Errors []InvalidParameterError
+}
+
+// Error implements the `error` interface for the
+// InvalidQueryError type.
+func (i InvalidQueryError) Error() string {
+	var messages = make([]string, 0, len(i.Errors))
+
+	for j := 0; j < len(i.Errors); j++ {
+		messages = append(messages, i.Errors[j].Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// ErrorObjects implements the serializers.ErrorObjects
+// interface for the InvalidQueryError type, describing
+// each invalid parameter.
+func (i InvalidQueryError) ErrorObjects() []serializers.Error {
+	var objects = make([]serializers.Error, 0, len(i.Errors))
+
+	for j := 0; j < len(i.Errors); j++ {
+		objects = append(objects, i.Errors[j].ErrorObjects()...)
+	}
+
+	return objects
+}
+
+// SortField is a single field of the sort
+// query parameter.
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// Page contains the members of the page query parameter
+// family. Members absent from the query are left as their
+// zero value.
+type Page struct {
+	Number int
+	Size   int
+	Offset int
+	Limit  int
+	Cursor string
+}
+
+// Result returns the serializers.PageResult describing the
+// requested page of a collection of unknown size; set the
+// total with the PageResult's WithTotal method if known.
+// Pages requested without a size or limit are given
+// serializers.DefaultPageSize, and pages requested without
+// a number are the first. The cursors of neighbouring pages
+// are left for the caller to set.
+func (p Page) Result() serializers.PageResult {
+	var result = serializers.PageResult{
+		Offset: p.Offset,
+		Limit:  p.Limit,
+		Number: p.Number,
+		Size:   p.Size,
+	}
+
+	return result.Defaults()
+}
+
+// Query contains the JSON API query parameters of a request.
+type Query struct {
+	// IncludePaths contains the relationship paths of the
+	// include parameter, nil if it was not provided.
+	IncludePaths serializers.IncludePaths
+	// Fieldsets contains the sparse fieldsets of the fields
+	// parameter family, nil if none were provided.
+	Fieldsets serializers.Fieldsets
+	// Sort contains the fields of the sort parameter,
+	// in order.
+	Sort []SortField
+	// Page contains the members of the page
+	// parameter family.
+	Page Page
+	// Filter contains the members of the filter
+	// parameter family, keyed by member name.
+	Filter map[string]string
+}
+
+// Options returns the serializers.Option values applying the
+// include parameter and sparse fieldsets of the Query to a
+// call to a Serializer's `Accept` method. They are merged
+// with copies of the Query's trees, as serializers.Include
+// and serializers.Fields are, keeping any set by earlier
+// options.
+func (q Query) Options() []serializers.Option {
+	var options = make([]serializers.Option, 0, len(q.Fieldsets)+1)
+
+	if nil != q.IncludePaths {
+		options = append(options, serializers.IncludeTree(q.IncludePaths))
+	}
+
+	for t, fieldset := range q.Fieldsets {
+		var names = make([]string, 0, len(fieldset))
+
+		for name := range fieldset {
+			names = append(names, name)
+		}
+
+		options = append(options, serializers.Fields(t, names...))
+	}
+
+	return options
+}
+
+// CheckIncludePaths returns an InvalidParameterError for the
+// first relationship path, in sorted order, of the include
+// parameter not contained in the IncludePaths tree `p` of the
+// paths that may be included, i.e. the result of
+// serializers.ParseIncludePaths("author,comments.author").
+// A nil tree allows every path. JSON API requires a server
+// to respond with 400 Bad Request to unknown paths.
+func (q Query) CheckIncludePaths(p serializers.IncludePaths) error {
+	if path, ok := unknownIncludePath(q.IncludePaths, p, ""); !ok {
+		return InvalidParameterError{IncludeParameter, path, "it is not a relationship path that may be included"}
+	}
+
+	return nil
+}
+
+// ParseQuery parses the JSON API query parameters of the
+// request `r` into a Query. Parameters not defined by the
+// JSON API specification are ignored. If any parameter is
+// invalid, an InvalidQueryError containing each violation
+// is returned.
+func ParseQuery(r *http.Request) (Query, error) {
+	var (
+		values = r.URL.Query()
+		keys   = make([]string, 0, len(values))
+		query  = Query{Filter: make(map[string]string)}
+		errs   = make([]InvalidParameterError, 0, 0)
+	)
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for i := 0; i < len(keys); i++ {
+		var (
+			value          = strings.Join(values[keys[i]], ",")
+			family, member = parseParameter(keys[i])
+			err            error
+		)
+
+		switch family {
+		case IncludeParameter:
+			if member != nil {
+				err = InvalidParameterError{keys[i], value, "it must not contain a member"}
+			} else {
+				query.IncludePaths = serializers.ParseIncludePaths(value)
+			}
+		case FieldsParameter:
+			if member == nil || 0 == len(*member) {
+				err = InvalidParameterError{keys[i], value, "it must name a type, i.e. `fields[type]`"}
+			} else {
+				if nil == query.Fieldsets {
+					query.Fieldsets = make(serializers.Fieldsets)
+				}
+
+				query.Fieldsets.Add(*member, value)
+			}
+		case SortParameter:
+			if member != nil {
+				err = InvalidParameterError{keys[i], value, "it must not contain a member"}
+			} else {
+				query.Sort, err = parseSort(keys[i], value)
+			}
+		case PageParameter:
+			err = parsePage(&query.Page, keys[i], member, value)
+		case FilterParameter:
+			if member == nil || 0 == len(*member) {
+				err = InvalidParameterError{keys[i], value, "it must name a filter, i.e. `filter[name]`"}
+			} else {
+				query.Filter[*member] = value
+			}
+		}
+
+		if nil != err {
+			errs = append(errs, err.(InvalidParameterError))
+		}
+	}
+
+	if 0 < len(errs) {
+		return query, InvalidQueryError{errs}
+	}
+
+	return query, nil
+}
+
+// parseParameter splits the query parameter name `p` into
+// its family and member, i.e. `page[size]` into `page` and
+// `size`. The member is nil for parameters without one.
+func parseParameter(p string) (string, *string) {
+	var open = strings.Index(p, "[")
+
+	if 0 > open || !strings.HasSuffix(p, "]") {
+		return p, nil
+	}
+
+	var member = p[open+1 : len(p)-1]
+
+	return p[:open], &member
+}
+
+// parseSort parses the value `v` of the sort
+// query parameter `p` into its SortFields.
+func parseSort(p, v string) ([]SortField, error) {
+	var (
+		fields  = strings.Split(v, ",")
+		sorting = make([]SortField, 0, len(fields))
+	)
+
+	for i := 0; i < len(fields); i++ {
+		var field = SortField{Field: strings.TrimSpace(fields[i])}
+
+		if strings.HasPrefix(field.Field, "-") {
+			field.Field = field.Field[1:]
+			field.Descending = true
+		}
+
+		if 0 == len(field.Field) {
+			return nil, InvalidParameterError{p, v, "it must not contain empty sort fields"}
+		}
+
+		sorting = append(sorting, field)
+	}
+
+	return sorting, nil
+}
+
+// parsePage sets the member `m` of the Page `p` from the
+// value `v` of the page query parameter `k`.
+func parsePage(p *Page, k string, m *string, v string) error {
+	if nil == m {
+		return InvalidParameterError{k, v, "it must name a member, i.e. `page[size]`"}
+	} else if PageCursor == *m {
+		p.Cursor = v
+		return nil
+	}
+
+	var (
+		target  *int
+		minimum = 1
+	)
+
+	switch *m {
+	case PageNumber:
+		target = &p.Number
+	case PageSize:
+		target = &p.Size
+	case PageOffset:
+		target, minimum = &p.Offset, 0
+	case PageLimit:
+		target = &p.Limit
+	default:
+		return InvalidParameterError{k, v, "it is not a supported page member"}
+	}
+
+	var n, err = strconv.Atoi(v)
+
+	if nil != err || minimum > n {
+		return InvalidParameterError{k, v, fmt.Sprintf("it must be an integer of at least %d", minimum)}
+	}
+
+	*target = n
+
+	return nil
+}
+
+// unknownIncludePath returns the first relationship path, in
+// sorted order and prefixed by `prefix`, of the IncludePaths
+// tree `q` not contained in the tree `p`, reporting false if
+// one was found.
+func unknownIncludePath(q, p serializers.IncludePaths, prefix string) (string, bool) {
+	if nil == p {
+		return "", true
+	}
+
+	var names = make([]string, 0, len(q))
+
+	for name := range q {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for i := 0; i < len(names); i++ {
+		var (
+			path        = prefix + names[i]
+			allowed, ok = p[names[i]]
+		)
+
+		if !ok {
+			return path, false
+		} else if path, ok = unknownIncludePath(q[names[i]], allowed, path+serializers.PathSeparator); !ok {
+			return path, false
+		}
+	}
+
+	return "", true
+}
@@ -0,0 +1,170 @@
+package httpjsonapi_test
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/httpjsonapi"
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidParameterError(t *testing.T) {
+	var err = httpjsonapi.InvalidParameterError{"page[size]", "-1", "it must be an integer of at least 1"}
+
+	assert.Equal(t, "query parameter `page[size]` with value `-1` is invalid, it must be an integer of at least 1", err.Error(), "failed to return correct error message for InvalidParameterError")
+}
+
+func TestInvalidQueryError(t *testing.T) {
+	var err = httpjsonapi.InvalidQueryError{[]httpjsonapi.InvalidParameterError{
+		{"fields", "title", "a"},
+		{"sort", "", "b"},
+	}}
+
+	assert.Equal(t, "query parameter `fields` with value `title` is invalid, a; query parameter `sort` with value `` is invalid, b", err.Error(), "failed to return correct error message for InvalidQueryError")
+}
+
+func TestParseQuery(t *testing.T) {
+	var (
+		r          = httptest.NewRequest("GET", "/posts?include=author,comments.author&fields[posts]=title,author&fields[persons]=name&sort=-created,title&page[number]=2&page[size]=10&page[cursor]=abc&filter[author]=1&other=x", nil)
+		query, err = httpjsonapi.ParseQuery(r)
+	)
+
+	assert.Nil(t, err, "received unexpected error from ParseQuery")
+	assert.Equal(t, serializers.ParseIncludePaths("author,comments.author"), query.IncludePaths, "failed to parse include parameter")
+	assert.Equal(t, serializers.Fieldsets{
+		"posts":   map[string]struct{}{"title": struct{}{}, "author": struct{}{}},
+		"persons": map[string]struct{}{"name": struct{}{}},
+	}, query.Fieldsets, "failed to parse fields parameters")
+	assert.Equal(t, []httpjsonapi.SortField{{"created", true}, {"title", false}}, query.Sort, "failed to parse sort parameter")
+	assert.Equal(t, httpjsonapi.Page{Number: 2, Size: 10, Cursor: "abc"}, query.Page, "failed to parse page parameters")
+	assert.Equal(t, map[string]string{"author": "1"}, query.Filter, "failed to parse filter parameters")
+}
+
+func TestParseQueryEmpty(t *testing.T) {
+	var query, err = httpjsonapi.ParseQuery(httptest.NewRequest("GET", "/posts", nil))
+
+	assert.Nil(t, err, "received unexpected error from ParseQuery")
+	assert.Nil(t, query.IncludePaths, "failed to leave include parameter unset")
+	assert.Nil(t, query.Fieldsets, "failed to leave fields parameters unset")
+	assert.Equal(t, httpjsonapi.Page{}, query.Page, "failed to leave page parameters unset")
+}
+
+func TestParseQueryInvalid(t *testing.T) {
+	var (
+		r      = httptest.NewRequest("GET", "/posts?fields=title&sort=title,,-&page[size]=0&page[offset]=-1&page[foo]=1&page=1&filter[]=x&include[posts]=author", nil)
+		_, err = httpjsonapi.ParseQuery(r)
+	)
+
+	assert.IsType(t, httpjsonapi.InvalidQueryError{}, err, "failed to return httpjsonapi.InvalidQueryError")
+
+	var parameters = make([]string, 0, 0)
+
+	for _, e := range err.(httpjsonapi.InvalidQueryError).Errors {
+		parameters = append(parameters, e.Parameter)
+	}
+
+	assert.Equal(t, []string{"fields", "filter[]", "include[posts]", "page", "page[foo]", "page[offset]", "page[size]", "sort"}, parameters, "failed to report each invalid parameter")
+}
+
+func TestQueryOptions(t *testing.T) {
+	var (
+		r        = httptest.NewRequest("GET", "/posts?include=comments&fields[posts]=title", nil)
+		query, _ = httpjsonapi.ParseQuery(r)
+		options  = serializers.NewOptions(query.Options()...)
+	)
+
+	assert.Equal(t, query.IncludePaths, options.IncludePaths, "failed to apply include parameter")
+	assert.Equal(t, query.Fieldsets, options.Fieldsets, "failed to apply fields parameters")
+}
+
+func TestQueryOptionsMerge(t *testing.T) {
+	var (
+		r        = httptest.NewRequest("GET", "/posts?include=comments&fields[posts]=title", nil)
+		query, _ = httpjsonapi.ParseQuery(r)
+		options  = serializers.NewOptions(append([]serializers.Option{
+			serializers.Include("author"),
+			serializers.Fields("posts", "body"),
+		}, query.Options()...)...)
+	)
+
+	options.IncludePaths["comments"]["author"] = serializers.IncludePaths{}
+	options.Fieldsets.Add("posts", "author")
+
+	assert.Equal(t, serializers.ParseIncludePaths("author,comments.author"), options.IncludePaths, "failed to merge include parameter")
+	assert.Equal(t, serializers.Fieldsets{
+		"posts": map[string]struct{}{"title": struct{}{}, "body": struct{}{}, "author": struct{}{}},
+	}, options.Fieldsets, "failed to merge fields parameters")
+	assert.Equal(t, serializers.ParseIncludePaths("comments"), query.IncludePaths, "modified include parameter of Query")
+	assert.Equal(t, serializers.Fieldsets{
+		"posts": map[string]struct{}{"title": struct{}{}},
+	}, query.Fieldsets, "modified fields parameters of Query")
+
+	query = httpjsonapi.Query{}
+	options = serializers.NewOptions(append([]serializers.Option{serializers.Include("author")}, query.Options()...)...)
+
+	assert.Equal(t, serializers.ParseIncludePaths("author"), options.IncludePaths, "failed to keep IncludePaths without include parameter")
+}
+
+func TestQueryCheckIncludePaths(t *testing.T) {
+	var (
+		allowed  = serializers.ParseIncludePaths("author,comments.author")
+		r        = httptest.NewRequest("GET", "/posts?include=comments.author,author", nil)
+		query, _ = httpjsonapi.ParseQuery(r)
+	)
+
+	assert.Nil(t, query.CheckIncludePaths(allowed), "received unexpected error for known include paths")
+	assert.Nil(t, query.CheckIncludePaths(nil), "received unexpected error when every include path is allowed")
+
+	r = httptest.NewRequest("GET", "/posts?include=Bogus,comments.post,comments.author", nil)
+	query, _ = httpjsonapi.ParseQuery(r)
+
+	assert.Equal(t, httpjsonapi.InvalidParameterError{"include", "Bogus", "it is not a relationship path that may be included"}, query.CheckIncludePaths(allowed), "failed to return httpjsonapi.InvalidParameterError for unknown include path")
+
+	query.IncludePaths = serializers.ParseIncludePaths("comments.post")
+
+	assert.Equal(t, "comments.post", query.CheckIncludePaths(allowed).(httpjsonapi.InvalidParameterError).Value, "failed to report nested unknown include path")
+}
+
+func TestInvalidQueryErrorObjects(t *testing.T) {
+	var err = httpjsonapi.InvalidQueryError{[]httpjsonapi.InvalidParameterError{
+		{"fields", "title", "a"},
+		{"sort", "", "b"},
+	}}
+
+	assert.Equal(t, []serializers.Error{
+		serializers.Error{Status: "400", Title: "Invalid Query Parameter", Detail: err.Errors[0].Error(), Source: serializers.ErrorSource{Parameter: "fields"}},
+		serializers.Error{Status: "400", Title: "Invalid Query Parameter", Detail: err.Errors[1].Error(), Source: serializers.ErrorSource{Parameter: "sort"}},
+	}, err.ErrorObjects(), "failed to describe each invalid parameter")
+}
+
+func TestPageResult(t *testing.T) {
+	var page = httpjsonapi.Page{Number: 2, Size: 10}
+
+	assert.Equal(t, serializers.PageResult{Number: 2, Size: 10, Limit: serializers.DefaultPageSize, Total: 42, HasTotal: true}, page.Result().WithTotal(42), "failed to describe requested page")
+
+	page = httpjsonapi.Page{}
+
+	assert.Equal(t, serializers.PageResult{
+		Number: 1,
+		Size:   serializers.DefaultPageSize,
+		Limit:  serializers.DefaultPageSize,
+	}, page.Result(), "failed to describe first page of default size")
+}
+
+func TestPageResultLinks(t *testing.T) {
+	var (
+		r        = httptest.NewRequest("GET", "/posts", nil)
+		query, _ = httpjsonapi.ParseQuery(r)
+	)
+
+	for _, paginator := range []serializers.Paginator{serializers.OffsetPaginator{}, serializers.PageNumberPaginator{}} {
+		for _, link := range paginator.Paginate(r.URL, query.Page.Result().WithTotal(25)) {
+			var _, err = httpjsonapi.ParseQuery(httptest.NewRequest("GET", link.(string), nil))
+
+			assert.Nil(t, err, "produced pagination link rejected by ParseQuery")
+		}
+	}
+}
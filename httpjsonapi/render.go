@@ -0,0 +1,157 @@
+package httpjsonapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+import (
+	"github.com/chuckpreslar/tranq"
+	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+// DefaultRenderer is the Renderer used by the package
+// level Render, RenderError and Wrap functions.
+var DefaultRenderer = &Renderer{Tranq: tranq.New(&configurators.V1{})}
+
+// Renderer writes JSON API documents serialized by its
+// tranq.Tranq as responses to HTTP requests.
+type Renderer struct {
+	*tranq.Tranq
+	// ErrorAdapter converts errors not implementing the
+	// serializers.ErrorObjects interface into JSON API
+	// error objects. If nil, such errors are described
+	// by the status code of the response alone, keeping
+	// their messages from clients.
+	ErrorAdapter serializers.ErrorAdapter
+}
+
+// statusAdapter is the serializers.ErrorAdapter used by
+// Renderers without one, describing errors by an error
+// object left for RenderError to give a status and title.
+var statusAdapter = serializers.ErrorAdapterFunc(func(e error) []serializers.Error {
+	return []serializers.Error{serializers.Error{}}
+})
+
+// Render negotiates the request `req`, then serializes `v` and
+// writes it to `w` with the status code `s` and a Content-Type
+// of MediaType. If negotiation or serialization fails, an error
+// document is written in its place and the error is returned.
+func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, s int, v interface{}, options ...serializers.Option) error {
+	if status, err := Negotiate(req); nil != err {
+		r.RenderError(w, req, status, err)
+		return err
+	}
+
+	return r.render(w, req, s, v, options...)
+}
+
+// RenderError writes a JSON API error document describing
+// the error `e` to `w` with the status code `s`. Error objects
+// without a status are given the status code `s`, along with
+// its status text as their title if they have none. Errors
+// not carrying error objects are described by the status
+// code `s` alone unless Renderer has an ErrorAdapter.
+func (r *Renderer) RenderError(w http.ResponseWriter, req *http.Request, s int, e error) error {
+	var objects = serializers.ErrorObjectsOf(e, r.errorAdapter())
+
+	for i := 0; i < len(objects); i++ {
+		if 0 < len(objects[i].Status) {
+			continue
+		}
+
+		objects[i].Status = strconv.Itoa(s)
+
+		if 0 == len(objects[i].Title) {
+			objects[i].Title = http.StatusText(s)
+		}
+	}
+
+	var document, err = (&serializers.ErrorSerializer{}).Accept(objects)
+
+	if nil != err {
+		return err
+	}
+
+	return write(w, s, document)
+}
+
+// ErrorStatus returns the status code of the first JSON API
+// error object describing the error `e` with an error status
+// code, or http.StatusInternalServerError if there is none.
+func (r *Renderer) ErrorStatus(e error) int {
+	var objects = serializers.ErrorObjectsOf(e, r.errorAdapter())
+
+	for i := 0; i < len(objects); i++ {
+		if status, err := strconv.Atoi(objects[i].Status); nil == err && http.StatusBadRequest <= status {
+			return status
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+// errorAdapter returns the ErrorAdapter of
+// Renderer, or statusAdapter if it has none.
+func (r *Renderer) errorAdapter() serializers.ErrorAdapter {
+	if nil == r.ErrorAdapter {
+		return statusAdapter
+	}
+
+	return r.ErrorAdapter
+}
+
+// render serializes `v` with the context of the request
+// `req` and writes it to `w` with the status code `s`,
+// writing an error document in its place if serialization
+// fails or the context is done.
+func (r *Renderer) render(w http.ResponseWriter, req *http.Request, s int, v interface{}, options ...serializers.Option) error {
+	if http.StatusNoContent == s {
+		w.WriteHeader(s)
+		return nil
+	}
+
+	var document, err = r.SerializeContext(req.Context(), v, options...)
+
+	if nil != err {
+		r.RenderError(w, req, http.StatusInternalServerError, err)
+		return err
+	}
+
+	return write(w, s, document)
+}
+
+// write encodes the document `m` and writes it to `w`
+// with the status code `s` and a Content-Type of
+// MediaType.
+func write(w http.ResponseWriter, s int, m map[string]interface{}) error {
+	var body, err = json.Marshal(m)
+
+	if nil != err {
+		s = http.StatusInternalServerError
+		body = []byte(`{"errors":[{"status":"500","title":"Internal Server Error"}]}`)
+	}
+
+	w.Header().Set("Content-Type", MediaType)
+	w.WriteHeader(s)
+
+	if _, werr := w.Write(body); nil == err {
+		err = werr
+	}
+
+	return err
+}
+
+// Render writes the JSON API document serialized from `v`
+// to `w` using the DefaultRenderer.
+func Render(w http.ResponseWriter, r *http.Request, s int, v interface{}, options ...serializers.Option) error {
+	return DefaultRenderer.Render(w, r, s, v, options...)
+}
+
+// RenderError writes a JSON API error document describing
+// the error `e` to `w` using the DefaultRenderer.
+func RenderError(w http.ResponseWriter, r *http.Request, s int, e error) error {
+	return DefaultRenderer.RenderError(w, r, s, e)
+}
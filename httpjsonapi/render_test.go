@@ -0,0 +1,188 @@
+package httpjsonapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq"
+	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/httpjsonapi"
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Post struct {
+	ID    int
+	Title string
+}
+
+var renderer = &httpjsonapi.Renderer{Tranq: tranq.New(configurators.NewV1(configurators.Settings{
+	TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
+		return strings.ToLower(s) + "s"
+	}),
+	AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+}))}
+
+func TestRender(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		r   = httptest.NewRequest("GET", "/posts/1", nil)
+		err = renderer.Render(w, r, http.StatusOK, Post{1, "Lorem"})
+	)
+
+	assert.Nil(t, err, "received unexpected error from Render")
+	assert.Equal(t, http.StatusOK, w.Code, "failed to write status code")
+	assert.Equal(t, httpjsonapi.MediaType, w.Header().Get("Content-Type"), "failed to set Content-Type")
+	assert.JSONEq(t, `{"data": {"type": "posts", "id": "1", "attributes": {"title": "Lorem"}}}`, w.Body.String(), "failed to write JSON API document")
+}
+
+func TestRenderOptions(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		r   = httptest.NewRequest("GET", "/posts/1", nil)
+		err = renderer.Render(w, r, http.StatusOK, Post{1, "Lorem"}, serializers.Fields("posts", ""))
+	)
+
+	assert.Nil(t, err, "received unexpected error from Render")
+	assert.JSONEq(t, `{"data": {"type": "posts", "id": "1"}}`, w.Body.String(), "failed to pass serializers.Option to Serialize")
+}
+
+func TestRenderNoContent(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		r   = httptest.NewRequest("DELETE", "/posts/1", nil)
+		err = renderer.Render(w, r, http.StatusNoContent, nil)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Render")
+	assert.Equal(t, http.StatusNoContent, w.Code, "failed to write status code")
+	assert.Empty(t, w.Body.String(), "wrote body for http.StatusNoContent")
+}
+
+func TestRenderNotAcceptable(t *testing.T) {
+	var (
+		w = httptest.NewRecorder()
+		r = httptest.NewRequest("GET", "/posts/1", nil)
+	)
+
+	r.Header.Set("Accept", httpjsonapi.MediaType+"; version=1")
+
+	var err = renderer.Render(w, r, http.StatusOK, Post{1, "Lorem"})
+
+	assert.IsType(t, httpjsonapi.NotAcceptableError{}, err, "failed to return httpjsonapi.NotAcceptableError")
+	assert.Equal(t, http.StatusNotAcceptable, w.Code, "failed to write status code")
+	assert.Contains(t, w.Body.String(), `"status":"406"`, "failed to write JSON API error document")
+}
+
+func TestRenderSerializationError(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		r   = httptest.NewRequest("GET", "/posts/1", nil)
+		err = renderer.Render(w, r, http.StatusOK, 1)
+	)
+
+	assert.IsType(t, serializers.UnsupportedKindError{}, err, "failed to return serialization error")
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "failed to write status code")
+	assert.JSONEq(t, `{"errors": [{"status": "500", "title": "Internal Server Error"}]}`, w.Body.String(), "failed to omit message of serialization error")
+}
+
+func TestRenderSerializationErrorHidesValues(t *testing.T) {
+	type Account struct {
+		ID       int
+		Password string `tranq:"-"`
+		Profile  struct{ Bio string }
+	}
+
+	var (
+		w   = httptest.NewRecorder()
+		r   = httptest.NewRequest("GET", "/accounts/1", nil)
+		err = renderer.Render(w, r, http.StatusOK, Account{ID: 1, Password: "hunter2"})
+	)
+
+	assert.IsType(t, serializers.UnlinkedResourceError{}, err, "failed to return serialization error")
+	assert.NotContains(t, w.Body.String(), "hunter2", "wrote value of ignored field to client")
+}
+
+func TestRenderError(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		r   = httptest.NewRequest("GET", "/posts/1", nil)
+		err = renderer.RenderError(w, r, http.StatusNotFound, errors.New("post not found"))
+	)
+
+	assert.Nil(t, err, "received unexpected error from RenderError")
+	assert.Equal(t, http.StatusNotFound, w.Code, "failed to write status code")
+	assert.Equal(t, httpjsonapi.MediaType, w.Header().Get("Content-Type"), "failed to set Content-Type")
+	assert.JSONEq(t, `{"errors": [{"status": "404", "title": "Not Found"}]}`, w.Body.String(), "failed to write JSON API error document")
+}
+
+func TestRenderErrorAdapter(t *testing.T) {
+	var (
+		w        = httptest.NewRecorder()
+		r        = httptest.NewRequest("GET", "/posts/1", nil)
+		adapting = &httpjsonapi.Renderer{
+			Tranq: renderer.Tranq,
+			ErrorAdapter: serializers.ErrorAdapterFunc(func(e error) []serializers.Error {
+				return []serializers.Error{serializers.Error{Detail: e.Error()}}
+			}),
+		}
+		err = adapting.RenderError(w, r, http.StatusNotFound, errors.New("post not found"))
+	)
+
+	assert.Nil(t, err, "received unexpected error from RenderError")
+	assert.JSONEq(t, `{"errors": [{"status": "404", "title": "Not Found", "detail": "post not found"}]}`, w.Body.String(), "failed to describe error with ErrorAdapter")
+}
+
+func TestRenderContext(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		w           = httptest.NewRecorder()
+		r           = httptest.NewRequest("GET", "/posts/1", nil).WithContext(ctx)
+	)
+
+	cancel()
+
+	var err = renderer.Render(w, r, http.StatusOK, Post{1, "Lorem"})
+
+	assert.Equal(t, context.Canceled, err, "failed to stop serializing once request context was done")
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "failed to write error status code")
+}
+
+func TestPackageRender(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		r   = httptest.NewRequest("GET", "/posts/1", nil)
+		err = httpjsonapi.Render(w, r, http.StatusOK, Post{1, "Lorem"})
+	)
+
+	assert.Nil(t, err, "received unexpected error from Render")
+	assert.JSONEq(t, `{"data": {"type": "Post", "id": "1", "attributes": {"Title": "Lorem"}}}`, w.Body.String(), "failed to render with DefaultRenderer")
+}
+
+func TestRenderErrorObjects(t *testing.T) {
+	var (
+		w        = httptest.NewRecorder()
+		r        = httptest.NewRequest("GET", "/posts?sort=", nil)
+		_, query = httpjsonapi.ParseQuery(r)
+		err      = renderer.RenderError(w, r, http.StatusBadRequest, query)
+	)
+
+	assert.Nil(t, err, "received unexpected error from RenderError")
+	assert.JSONEq(t, `{"errors": [{
+		"status": "400",
+		"title": "Invalid Query Parameter",
+		"detail": "query parameter `+"`sort`"+` with value `+"``"+` is invalid, it must not contain empty sort fields",
+		"source": {"parameter": "sort"}
+	}]}`, w.Body.String(), "failed to write JSON API error document")
+}
+
+func TestErrorStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotAcceptable, renderer.ErrorStatus(httpjsonapi.NotAcceptableError{}), "failed to return status of error object")
+	assert.Equal(t, http.StatusInternalServerError, renderer.ErrorStatus(errors.New("failed")), "failed to return http.StatusInternalServerError")
+}
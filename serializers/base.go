@@ -1,8 +1,15 @@
 package serializers
 
 import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -11,6 +18,13 @@ const (
 	ID = "ID"
 )
 
+const (
+	// DefaultMaxDepth is the maximum number of
+	// resources nested along a single path when
+	// a serializer's MaxDepth is not set.
+	DefaultMaxDepth = 32
+)
+
 const (
 	// TranqLink represents the struct tag expected
 	// for linking nested resources.
@@ -19,10 +33,17 @@ const (
 	// contains the unformatted JSON API href
 	// attribute.
 	TranqHref = "tranq_href"
-	// TranqIgnore ...
+	// TranqIgnore represents the struct tag marking
+	// a field to be skipped entirely, equivalent
+	// to `tranq:"-"`.
 	TranqIgnore = "tranq_ignore"
 )
 
+var (
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
 // UninterfaceableValueError occurs when a reflect.Value
 // cannot have its `Interface` method called during
 // serialization.
@@ -65,16 +86,87 @@ func (u UnlinkedResourceError) Error() string {
 
 // MissingIdentifierError occurs when a resource
 // is flagged for linking via a struct tag but
-// has no field named by the constant string
-// contained in ID.
+// has no identifier located by the serializer's
+// IdentifierStrategy, by default the field named
+// by the constant string contained in ID.
 type MissingIdentifierError struct {
 	Value reflect.Value
+	// Strategy is the IdentifierStrategy failing to
+	// locate the identifier, DefaultIdentifierStrategy
+	// if nil.
+	Strategy IdentifierStrategy
 }
 
 // Error implements the `error` interface for the
-// MissingIdentifierError type.
+// MissingIdentifierError type, naming the field, tag
+// or method its Strategy locates identifiers with.
 func (m MissingIdentifierError) Error() string {
-	return fmt.Sprintf("value `%s` is missing identifier field `%s`", m.Value, ID)
+	return fmt.Sprintf("value `%s` is missing identifier %s", m.Value, describeIdentifierStrategy(m.Strategy))
+}
+
+// UnsupportedMapKeyError occurs when a Serializer encounters
+// a map whose keys cannot be represented as JSON object keys.
+type UnsupportedMapKeyError struct {
+	Type reflect.Type
+}
+
+// Error implements the `error` interface for the
+// UnsupportedMapKeyError type.
+func (u UnsupportedMapKeyError) Error() string {
+	return fmt.Sprintf("map key type `%s` must be a string, integer or implement encoding.TextMarshaler", u.Type)
+}
+
+// CycleError occurs when a resource is encountered
+// along the path of resources already being serialized.
+type CycleError struct {
+	Path [][2]string
+}
+
+// Error implements the `error` interface for the
+// CycleError type.
+func (c CycleError) Error() string {
+	var path = make([]string, 0, len(c.Path))
+
+	for i := 0; i < len(c.Path); i++ {
+		path = append(path, c.Path[i][0]+":"+c.Path[i][1])
+	}
+
+	return fmt.Sprintf("unable to resolve cycle in linked resources along path `%s`", strings.Join(path, " -> "))
+}
+
+// DepthError occurs when resources nested other than
+// by links, such as within map attributes, grow beyond
+// the serializer's maximum depth. Linked resources past
+// it are linked by reference only.
+type DepthError struct {
+	Path     [][2]string
+	MaxDepth int
+}
+
+// Error implements the `error` interface for the
+// DepthError type.
+func (d DepthError) Error() string {
+	var path = make([]string, 0, len(d.Path))
+
+	for i := 0; i < len(d.Path); i++ {
+		path = append(path, d.Path[i][0]+":"+d.Path[i][1])
+	}
+
+	return fmt.Sprintf("resources nested along path `%s` exceed the maximum depth of %d", strings.Join(path, " -> "), d.MaxDepth)
+}
+
+// NestingError occurs when maps are nested within each
+// other beyond the serializer's maximum depth, such as a
+// map containing itself.
+type NestingError struct {
+	Type     reflect.Type
+	MaxDepth int
+}
+
+// Error implements the `error` interface for the
+// NestingError type.
+func (n NestingError) Error() string {
+	return fmt.Sprintf("map of type `%s` is nested beyond the maximum depth of %d", n.Type, n.MaxDepth)
 }
 
 // HrefFormatter provides an interface for formatting
@@ -115,8 +207,10 @@ func (f NamingFormatterFunc) FormatName(n string) string {
 
 // Dereference attempts to dereference argument `i` from
 // a pointer or interface to a base type, returning its
-// reflect.Value, reflect.Type and reflect.Kind. If
-// a reflect.Value cannot have its `Interface` method
+// reflect.Value, reflect.Type and reflect.Kind. Nil
+// pointers are not followed, their reflect.Value is
+// returned as is with a reflect.Kind of reflect.Ptr.
+// If a reflect.Value cannot have its `Interface` method
 // called without panicking, an UninterfaceableValueError
 // is returned.
 func Dereference(i interface{}) (reflect.Value, reflect.Type, reflect.Kind, error) {
@@ -129,7 +223,15 @@ func Dereference(i interface{}) (reflect.Value, reflect.Type, reflect.Kind, erro
 	v = reflect.ValueOf(i)
 	k = v.Kind()
 
+	if k == reflect.Invalid {
+		return v, nil, k, nil
+	}
+
 	if k == reflect.Ptr || k == reflect.Interface {
+		if v.IsNil() {
+			return v, v.Type(), k, nil
+		}
+
 		v = v.Elem()
 
 		if v.CanInterface() {
@@ -144,6 +246,30 @@ func Dereference(i interface{}) (reflect.Value, reflect.Type, reflect.Kind, erro
 	return v, t, k, nil
 }
 
+// IsMarshaler reports whether values of type `t`, or
+// pointers to them, implement either json.Marshaler or
+// encoding.TextMarshaler and should therefore be treated
+// as leaf values, such as time.Time, rather than walked.
+func IsMarshaler(t reflect.Type) bool {
+	if nil == t {
+		return false
+	} else if t.Implements(jsonMarshaler) || t.Implements(textMarshaler) {
+		return true
+	} else if t.Kind() == reflect.Ptr {
+		return false
+	}
+
+	var p = reflect.PtrTo(t)
+
+	return p.Implements(jsonMarshaler) || p.Implements(textMarshaler)
+}
+
+// IsField reports whether the struct field `f` is the
+// direct field at index `i` of its struct type.
+func IsField(f reflect.StructField, i int) bool {
+	return 1 == len(f.Index) && i == f.Index[0]
+}
+
 // TypeName attempts to resolved the name of a type,
 // both native and user defined. If argument `i`
 // cannot be successfully passed to Dereference,
@@ -188,36 +314,98 @@ type Base struct {
 	// `href` attribute value when linked resources
 	// are encountered during serialization.
 	HrefFormatter HrefFormatter
-	// RootContext is the base map[string]interface{}
-	// created to contain the serialized JSON API
-	// response.
-	RootContext map[string]interface{}
-	// LinkedDocuments ...
-	LinkedDocuments map[interface{}]struct{}
+	// SelfTemplates contains the URL templates of the
+	// `self` links of resources keyed by formatted type
+	// name, taking precedence over the TranqSelf tag.
+	SelfTemplates map[string]string
+	// LinkTemplates determines whether relationships with
+	// a TranqHref are also described by URL templates under
+	// the top level JSON API reserved string `links`, keyed
+	// by owner type name and attribute, as JSON API drafts
+	// allowed.
+	LinkTemplates bool
+	// FormatMapKeys determines whether keys of
+	// serialized maps are formatted with the
+	// AttributeNameFormatter NamingFormatter.
+	FormatMapKeys bool
+	// UseJSONTags determines whether the `json` struct
+	// tag is read for attribute names and options when
+	// a field has no `tranq` struct tag.
+	UseJSONTags bool
+	// IdentifierStrategy is used to locate the
+	// identifiers of resources during serialization.
+	// If nil, DefaultIdentifierStrategy is used.
+	IdentifierStrategy IdentifierStrategy
+	// MaxDepth is the maximum number of resources nested
+	// along a single path. Linked resources past it are
+	// linked by reference only, other resources fail with
+	// a DepthError and maps nested within each other
+	// with a NestingError. If zero, DefaultMaxDepth
+	// is used.
+	MaxDepth int
+	// ConflictPolicy determines how a linked resource
+	// appearing more than once within a document with
+	// differing values is resolved.
+	ConflictPolicy ConflictPolicy
+	// TypeCache caches the reflection metadata of types
+	// across serializers sharing Base's settings. If nil,
+	// metadata is cached for a single call to Accept.
+	TypeCache *TypeCache
+	// State contains the state of the call to Accept
+	// in progress, empty outside of one.
+	State
 	// ReservedStrings is a structure containing
 	// JSON API reserved words formatted with the
 	// AttributeNameFormatter NamingFormatter.
 	ReservedStrings struct {
-		ID     string
-		IDs    string
-		Links  string
-		Linked string
-		Meta   string
-		Data   string
-		Type   string
-		Href   string
+		ID            string
+		IDs           string
+		Links         string
+		Linked        string
+		Meta          string
+		Data          string
+		Type          string
+		Href          string
+		Attributes    string
+		Relationships string
+		Included      string
+		Related       string
+		Self          string
 	}
 }
 
 // Accept implements the `Accept` method required
-// by the Serializer interface.
-func (b *Base) Accept(i interface{}) (map[string]interface{}, error) {
-	var (
-		err       error
-		namespace string
-		mapping   map[string]interface{}
-	)
+// by the Serializer interface, serializing `i`
+// with a Fork of Base.
+func (b *Base) Accept(i interface{}, options ...Option) (map[string]interface{}, error) {
+	return b.AcceptContext(context.Background(), i, options...)
+}
+
+// AcceptContext implements the `AcceptContext` method
+// required by the Serializer interface, serializing `i`
+// with a Fork of Base carrying `ctx`.
+func (b *Base) AcceptContext(ctx context.Context, i interface{}, options ...Option) (map[string]interface{}, error) {
+	var namespace, err = b.namespace(i)
+
+	if nil != err {
+		return nil, err
+	}
+
+	var fork = b.ForkContext(ctx)
 
+	return fork.AcceptDocument(namespace, func() (interface{}, error) {
+		fork.MarkCollection(i)
+		return fork.Serialize(i)
+	}, options...)
+}
+
+// AcceptDocument performs a call to Accept, keying the document
+// returned by the serialization function `f` by the formatted
+// type name `n` once Base's State has been reset with the
+// Options provided. It allows serializers for known types to
+// reuse Base's documents without reflection, and is called
+// on a Fork of a shared Base.
+func (b *Base) AcceptDocument(n string, f func() (interface{}, error), options ...Option) (mapping map[string]interface{}, err error) {
 	defer func() {
 		if temp := recover(); nil != temp {
 			if _, ok := temp.(error); ok {
@@ -230,19 +418,210 @@ func (b *Base) Accept(i interface{}) (map[string]interface{}, error) {
 		}
 	}()
 
-	if namespace, err = TypeName(i); nil != err {
+	if err = b.Err(); nil != err {
 		return nil, err
 	}
 
-	namespace = b.FormatTypeName(namespace)
 	mapping = make(map[string]interface{})
-	b.RootContext = mapping
 
-	mapping[namespace], err = b.Serialize(i)
+	var o = b.reset(mapping, options)
+
+	if nil == b.TypeCache {
+		defer func() { b.types = nil }()
+	}
+
+	if mapping[n], err = f(); nil != err {
+		return mapping, err
+	}
+
+	b.addMembers(mapping, o)
 
 	return mapping, err
 }
 
+// reset prepares Base's State for serializing a new document
+// into `mapping` with the Options produced by `options`,
+// returning the Options.
+func (b *Base) reset(mapping map[string]interface{}, options []Option) Options {
+	var o = NewOptions(options...)
+
+	b.RootContext = mapping
+	b.LinkedDocuments = make(map[[2]string]map[string]interface{})
+	b.IncludePaths = o.IncludePaths
+	b.Fieldsets = o.Fieldsets
+	b.StrictFields = o.StrictFields
+	b.Path = nil
+
+	if nil == b.TypeCache {
+		b.types = new(TypeCache)
+	}
+
+	return o
+}
+
+// addMembers adds the top level links and meta
+// information of the Options `o` to `mapping`.
+func (b *Base) addMembers(mapping map[string]interface{}, o Options) {
+	if 0 < len(o.Links) {
+		var links = b.Links(mapping)
+
+		for key, value := range o.Links {
+			links[key] = value
+		}
+	}
+
+	if 0 < len(o.Meta) {
+		mapping[b.ReservedStrings.Meta] = o.Meta
+	}
+}
+
+// Stream implements the StreamingSerializer interface for the
+// Base type, writing the members of each primary resource directly
+// from its struct fields rather than building its document first.
+// Linked documents are built and deduplicated as Accept does, and
+// buffered until the primary data has been written. If the
+// formatted type name would not place the primary data first in
+// the document, the primary data is written to a buffer until the
+// members preceding it have been. As with Accept, `i` is
+// serialized with a Fork of Base.
+func (b *Base) Stream(e *Encoder, i interface{}, options ...Option) error {
+	return b.StreamContext(context.Background(), e, i, options...)
+}
+
+// StreamContext implements the StreamingSerializer interface
+// for the Base type, writing the document for `i` as Stream
+// does with a Fork of Base carrying `ctx`.
+func (b *Base) StreamContext(ctx context.Context, e *Encoder, i interface{}, options ...Option) error {
+	var namespace, err = b.namespace(i)
+
+	if nil != err {
+		return err
+	}
+
+	return b.ForkContext(ctx).stream(e, namespace, i, options)
+}
+
+// namespace returns the formatted type name keying the document
+// of the primary data `i`. Maps, and collections of maps, have
+// no type name to key a document by and fail with an
+// UnsupportedKindError; they are supported only as attributes.
+func (b *Base) namespace(i interface{}) (string, error) {
+	var _, t, _, err = Dereference(i)
+
+	if nil != err {
+		return "", err
+	}
+
+	for nil != t && (t.Kind() == reflect.Array || t.Kind() == reflect.Slice || t.Kind() == reflect.Ptr) {
+		t = t.Elem()
+	}
+
+	if nil != t && t.Kind() == reflect.Map {
+		return "", UnsupportedKindError{t.Kind(), b}
+	}
+
+	var namespace string
+
+	if namespace, err = TypeName(i); nil != err {
+		return "", err
+	}
+
+	return b.FormatTypeName(namespace), nil
+}
+
+// stream writes the document for `i` keyed by the formatted
+// type name `n` as Stream does, modifying Base's State.
+func (b *Base) stream(e *Encoder, n string, i interface{}, options []Option) (err error) {
+	defer func() {
+		if temp := recover(); nil != temp {
+			if _, ok := temp.(error); ok {
+				err = temp.(error)
+			} else {
+				err = fmt.Errorf("%s", temp)
+			}
+		}
+	}()
+
+	if n == b.ReservedStrings.Linked || n == b.ReservedStrings.Links || n == b.ReservedStrings.Meta {
+		var mapping map[string]interface{}
+
+		mapping, err = b.AcceptDocument(n, func() (interface{}, error) {
+			b.MarkCollection(i)
+			return b.Serialize(i)
+		}, options...)
+
+		if nil != err {
+			return err
+		}
+
+		return e.WriteValue(mapping)
+	}
+
+	if err = b.Err(); nil != err {
+		return err
+	}
+
+	var (
+		o       = b.reset(make(map[string]interface{}), options)
+		first   = n < b.ReservedStrings.Linked && n < b.ReservedStrings.Links && n < b.ReservedStrings.Meta
+		data    = e
+		buffer  bytes.Buffer
+		written = 0
+	)
+
+	if !first {
+		data = NewEncoder(&buffer, e.Serializer)
+	} else if err = e.WriteRaw("{"); nil != err {
+		return err
+	} else if err = e.writeMember(&written, n); nil != err {
+		return err
+	}
+
+	b.MarkCollection(i)
+
+	if err = b.streamValue(data, i); nil != err {
+		return err
+	} else if !first {
+		if err = data.buffer.Flush(); nil != err {
+			return err
+		}
+	}
+
+	b.addMembers(b.RootContext, o)
+
+	var keys = make([]string, 0, len(b.RootContext)+1)
+
+	for key := range b.RootContext {
+		keys = append(keys, key)
+	}
+
+	if !first {
+		keys = append(keys, n)
+
+		if err = e.WriteRaw("{"); nil != err {
+			return err
+		}
+	}
+
+	sort.Strings(keys)
+
+	for j := 0; j < len(keys); j++ {
+		if err = e.writeMember(&written, keys[j]); nil != err {
+			return err
+		} else if keys[j] == n {
+			_, err = e.buffer.Write(buffer.Bytes())
+		} else {
+			err = e.WriteValue(b.RootContext[keys[j]])
+		}
+
+		if nil != err {
+			return err
+		}
+	}
+
+	return e.WriteRaw("}")
+}
+
 // Serialize allows for the recursive serialization
 // of base and user defeined types.
 func (b *Base) Serialize(i interface{}) (interface{}, error) {
@@ -255,8 +634,12 @@ func (b *Base) Serialize(i interface{}) (interface{}, error) {
 
 	if value, _, kind, err = Dereference(i); nil != err {
 		return nil, err
+	} else if kind == reflect.Invalid {
+		return b.SerializeInvalid(value)
 	} else if !value.CanInterface() {
 		return nil, UninterfaceableValueError{value}
+	} else if kind != reflect.Ptr && IsMarshaler(value.Type()) {
+		return b.SerializeMarshaler(value)
 	}
 
 	switch kind {
@@ -320,8 +703,13 @@ func (b *Base) Serialize(i interface{}) (interface{}, error) {
 }
 
 // SerializeInvalid attempts to serialize a reflect.Value with a reflect.Kind
-// of reflect.Invalid.
+// of reflect.Invalid. The zero reflect.Value, produced by nil
+// interfaces, is serialized as nil.
 func (b *Base) SerializeInvalid(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
 	return nil, UnsupportedKindError{v.Kind(), b}
 }
 
@@ -464,15 +852,80 @@ func (b *Base) SerializeInterface(v reflect.Value) (interface{}, error) {
 }
 
 // SerializeMap attempts to serialize a reflect.Value with a reflect.Kind
-// of reflect.Map.
+// of reflect.Map. Maps nested within each other beyond Base's MaxDepth
+// fail with a NestingError.
 func (b *Base) SerializeMap(v reflect.Value) (interface{}, error) {
-	return nil, UnsupportedKindError{v.Kind(), b}
+	if v.Kind() != reflect.Map {
+		return nil, UnsupportedKindError{v.Kind(), b}
+	} else if v.IsNil() {
+		return nil, nil
+	} else if b.Depth() <= b.nesting {
+		return nil, NestingError{v.Type(), b.Depth()}
+	}
+
+	b.nesting++
+	defer func() { b.nesting-- }()
+
+	var mapping = make(map[string]interface{}, v.Len())
+
+	for _, key := range v.MapKeys() {
+		var (
+			element = v.MapIndex(key)
+			name    string
+			result  interface{}
+			err     error
+		)
+
+		if name, err = b.SerializeMapKey(key); nil != err {
+			return nil, err
+		} else if !element.CanInterface() {
+			return nil, UninterfaceableValueError{element}
+		} else if result, err = b.Serialize(element.Interface()); nil != err {
+			return nil, err
+		}
+
+		if b.FormatMapKeys {
+			name = b.FormatAttributeName(name)
+		}
+
+		mapping[name] = result
+	}
+
+	return mapping, nil
+}
+
+// SerializeMapKey attempts to convert a map key to a string
+// following the rules of the `encoding/json` package, with
+// keys implementing encoding.TextMarshaler taking precedence.
+func (b *Base) SerializeMapKey(v reflect.Value) (string, error) {
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		var text, err = marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+
+	return "", UnsupportedMapKeyError{v.Type()}
 }
 
 // SerializePtr attempts to serialize a reflect.Value with a reflect.Kind
-// of reflect.Ptr.
+// of reflect.Ptr. Nil pointers are serialized as nil while
+// all others are followed.
 func (b *Base) SerializePtr(v reflect.Value) (interface{}, error) {
-	return nil, UnsupportedKindError{v.Kind(), b}
+	if v.Kind() != reflect.Ptr {
+		return nil, UnsupportedKindError{v.Kind(), b}
+	} else if v.IsNil() {
+		return nil, nil
+	}
+
+	return b.Serialize(v.Elem().Interface())
 }
 
 // SerializeSlice attempts to serialize a reflect.Value with a reflect.Kind
@@ -488,105 +941,293 @@ func (b *Base) SerializeString(v reflect.Value) (interface{}, error) {
 }
 
 // SerializeStruct attempts to serialize a reflect.Value with a reflect.Kind
-// of reflect.Struct.
+// of reflect.Struct, unless the Context of Base's State is done.
 func (b *Base) SerializeStruct(v reflect.Value) (interface{}, error) {
+	if err := b.Err(); nil != err {
+		return nil, err
+	}
+
 	var (
-		mapping = make(map[string]interface{})
-		t       = v.Type()
+		mapping  = make(map[string]interface{})
+		t        = v.Type()
+		metadata = b.Metadata(t)
 	)
 
-	for i := 0; i < v.NumField(); i++ {
-		var temp = v.Field(i)
+	if id, ok := metadata.identifier(v); ok {
+		var err error
 
-		if !temp.CanInterface() {
-			return nil, UninterfaceableValueError{temp}
+		if err = b.EnterDocument(v); nil != err {
+			return nil, err
 		}
 
-		var val, typ, kind, err = Dereference(temp.Interface())
+		defer b.LeaveDocument()
 
-		if nil != err {
+		if mapping[b.IdentifierName(t)], err = b.Serialize(id); nil != err {
 			return nil, err
 		}
+	}
+
+	var fieldset, sparse, err = b.Fieldset(t)
+
+	if nil != err {
+		return nil, err
+	}
 
+	for i := 0; i < len(metadata.Fields); i++ {
 		var (
-			field = t.Field(i)
-			attr  = b.FormatAttributeName(field.Name)
+			field                     = &metadata.Fields[i]
+			val, typ, kind, role, err = b.classifyField(v, field, fieldset, sparse)
 		)
 
-		if kind == reflect.Struct || kind == reflect.Array || kind == reflect.Slice {
-			if "true" != field.Tag.Get(TranqLink) {
-				return nil, UnlinkedResourceError{v}
-			} else if err = b.LinkStructField(mapping, v, val, typ, kind, field); nil != err {
+		if nil != err {
+			return nil, err
+		} else if fieldOmitted == role {
+			continue
+		} else if fieldLinked == role {
+			if err = b.LinkStructField(mapping, v, val, typ, kind, field.Field); nil != err {
 				return nil, err
 			}
-
-		} else if mapping[attr], err = b.Serialize(val.Interface()); nil != err {
+		} else if mapping[field.Name], err = b.attributeValue(val, role); nil != err {
 			return nil, err
 		}
 	}
 
+	var (
+		self string
+		ok   bool
+	)
+
+	if self, ok, err = b.SelfLink(v); nil != err {
+		return nil, err
+	} else if ok {
+		var links = b.Links(mapping)
+
+		if _, found := links[b.ReservedStrings.Self]; found {
+			return nil, SelfLinkConflictError{v.Type(), b.ReservedStrings.Self}
+		}
+
+		links[b.ReservedStrings.Self] = self
+	}
+
+	var meta map[string]interface{}
+
+	if meta, err = b.ResourceMeta(v); nil != err {
+		return nil, err
+	} else if 0 < len(meta) {
+		mapping[b.ReservedStrings.Meta] = meta
+	}
+
 	return mapping, nil
 }
 
+// SerializeMarshaler attempts to serialize a reflect.Value whose
+// type implements json.Marshaler or encoding.TextMarshaler,
+// returning it as a leaf value to be encoded by its own
+// marshaling methods. Values implementing either interface
+// only through a pointer receiver are returned as a pointer
+// to a copy of the value.
+func (b *Base) SerializeMarshaler(v reflect.Value) (interface{}, error) {
+	var t = v.Type()
+
+	if t.Implements(jsonMarshaler) || t.Implements(textMarshaler) {
+		return v.Interface(), nil
+	}
+
+	var ptr = reflect.New(t)
+	ptr.Elem().Set(v)
+
+	return ptr.Interface(), nil
+}
+
+// SerializeAttribute serializes the value `i` of an attribute of
+// the struct value `p` that is not linked, as SerializeStruct
+// would. Structs, arrays and slices that do not implement either
+// json.Marshaler or encoding.TextMarshaler fail with an
+// UnlinkedResourceError.
+func (b *Base) SerializeAttribute(p, i interface{}) (interface{}, error) {
+	var val, typ, kind, err = Dereference(i)
+
+	if nil != err {
+		return nil, err
+	}
+
+	var role = classifyValue(typ, kind, false)
+
+	if fieldLinked == role {
+		return nil, UnlinkedResourceError{reflect.ValueOf(p)}
+	}
+
+	return b.attributeValue(val, role)
+}
+
 // SerializeUnsafePointer attempts to serialize a reflect.Value with a reflect.Kind
 // of reflect.UnsafePointer.
 func (b *Base) SerializeUnsafePointer(v reflect.Value) (interface{}, error) {
 	return nil, UnsupportedKindError{v.Kind(), b}
 }
 
-// IsZeroValue ...
+// IsZeroValue reports whether the value `i` of reflect.Kind `k`
+// is the zero value for its type. Types implementing an
+// `IsZero() bool` method, such as time.Time, are deferred
+// to. Nil pointers, interfaces, channels and functions, empty
+// slices and maps, and arrays and structs whose elements or
+// fields are all zero values are considered zero values.
 func (b *Base) IsZeroValue(k reflect.Kind, i interface{}) bool {
-	switch k {
-
+	if nil == i {
+		return true
 	}
-	return false
-}
 
-// IsCompoundDocument ...
-func (b *Base) IsCompoundDocument(v reflect.Value) bool {
-	var (
-		fields = make([]int, 0, 0)
-		t      = v.Type()
-	)
+	var v = reflect.ValueOf(i)
 
-	for i := 0; i < v.NumField(); i++ {
-		var (
-			value = v.Field(i)
-			field = t.Field(i)
-			kind  = value.Kind()
-		)
+	if (reflect.Ptr == v.Kind() || reflect.Interface == v.Kind()) && v.IsNil() {
+		return true
+	} else if zeroer, ok := i.(interface {
+		IsZero() bool
+	}); ok {
+		return zeroer.IsZero()
+	}
 
-		if !value.CanInterface() {
-			continue
-		} else if "true" == field.Tag.Get(TranqIgnore) {
-			continue
-		} else if b.IsZeroValue(kind, value.Interface()) {
-			continue
+	switch k {
+	case reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	case reflect.Map, reflect.Slice:
+		return 0 == v.Len()
+	case reflect.Array:
+		for j := 0; j < v.Len(); j++ {
+			if !b.IsZeroReflectValue(v.Index(j)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Struct:
+		for j := 0; j < v.NumField(); j++ {
+			if !b.IsZeroReflectValue(v.Field(j)) {
+				return false
+			}
 		}
 
-		fields = append(fields, i)
+		return true
 	}
 
-	return 1 < len(fields)
+	return v.IsZero()
 }
 
-// LinkCompoundDocument ...
-func (b *Base) LinkCompoundDocument(v reflect.Value, n string) error {
-	var (
-		linked map[string]interface{}
-		result interface{}
-		err    error
-		ok     bool
-	)
+// IsOmitted reports whether the field value `v` is skipped by
+// its Tag `t`, having the `omitempty` option and a zero value,
+// or an empty value as encoding/json defines it if the Tag was
+// parsed from the `json` struct tag.
+func (b *Base) IsOmitted(t Tag, v reflect.Value) bool {
+	if !t.OmitEmpty {
+		return false
+	} else if t.JSON {
+		return IsEmptyJSONValue(v.Interface())
+	}
 
-	if _, ok = b.LinkedDocuments[v.Interface()]; !ok {
-		b.LinkedDocuments[v.Interface()] = struct{}{}
-	} else {
-		return nil
+	return b.IsZeroValue(v.Kind(), v.Interface())
+}
+
+// IsZeroReflectValue reports whether the reflect.Value `v` is
+// the zero value for its type, deferring to IsZeroValue when
+// `v` can have its `Interface` method called.
+func (b *Base) IsZeroReflectValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	} else if !v.CanInterface() {
+		return v.IsZero()
 	}
 
-	if linked, ok = b.RootContext[b.ReservedStrings.Linked].(map[string]interface{}); !ok {
-		linked = make(map[string]interface{})
+	return b.IsZeroValue(v.Kind(), v.Interface())
+}
+
+// IsZero reports whether the value `i` is the zero
+// value for its type, as IsZeroValue does for the
+// reflect.Kind of its dynamic type.
+func (b *Base) IsZero(i interface{}) bool {
+	return b.IsZeroValue(reflect.ValueOf(i).Kind(), i)
+}
+
+// IsCompoundDocument reports whether the struct value `v`
+// carries more than its identifier, that is whether any
+// field other than the one located by the IdentifierStrategy
+// is set. Compound documents are sideloaded in full, all
+// others are linked by reference only.
+func (b *Base) IsCompoundDocument(v reflect.Value) bool {
+	var metadata = b.Metadata(v.Type())
+
+	for _, fields := range [][]FieldMetadata{metadata.Fields, metadata.Meta} {
+		for i := 0; i < len(fields); i++ {
+			var value = v.Field(fields[i].Field.Index[0])
+
+			if !value.CanInterface() {
+				continue
+			} else if b.IsZeroValue(value.Kind(), value.Interface()) {
+				continue
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// LinkCompoundDocument serializes the struct value `v` and
+// adds it to the documents of type `n` stored under the JSON
+// API reserved string `linked`. Resources already linked are
+// resolved according to Base's ConflictPolicy, resources
+// along Base's Path are linked by reference only.
+func (b *Base) LinkCompoundDocument(v reflect.Value, n string) error {
+	if b.IsVisiting(v) {
+		return nil
+	}
+
+	var key, err = b.DocumentKey(v)
+
+	if nil != err {
+		return err
+	}
+
+	return b.LinkDocument(key, n, func() (interface{}, error) {
+		return b.Serialize(v.Interface())
+	})
+}
+
+// LinkDocument adds the document returned by the serialization
+// function `f` for the resource identified by `k` to the documents
+// of type `n` stored under the JSON API reserved string `linked`,
+// as LinkCompoundDocument does. Once Base's Path has reached
+// its MaxDepth resources are linked by reference only.
+func (b *Base) LinkDocument(k [2]string, n string, f func() (interface{}, error)) error {
+	if b.IsVisitingKey(k) || b.IsMaxDepth() {
+		return nil
+	}
+
+	var existing, found = b.LinkedDocuments[k]
+
+	if found && (nil == existing || b.ConflictPolicy == ConflictFirstWins) {
+		return nil
+	} else if !found {
+		existing = make(map[string]interface{})
+		b.LinkedDocuments[k] = existing
+	}
+
+	var result, err = f()
+
+	if nil != err {
+		return err
+	}
+
+	var document, _ = result.(map[string]interface{})
+
+	if found {
+		return b.ResolveConflict(k, existing, document)
+	}
+
+	var linked, ok = b.RootContext[b.ReservedStrings.Linked].(map[string]interface{})
+
+	if !ok {
+		linked = make(map[string]interface{})
 		b.RootContext[b.ReservedStrings.Linked] = linked
 	}
 
@@ -594,14 +1235,76 @@ func (b *Base) LinkCompoundDocument(v reflect.Value, n string) error {
 		linked[n] = make([]interface{}, 0, 0)
 	}
 
-	if result, err = b.Serialize(v.Interface()); nil != err {
-		return err
+	mergeDocument(existing, document)
+	linked[n] = append(linked[n].([]interface{}), existing)
+
+	return nil
+}
+
+// MarkDocument records the struct value `r` as present in the
+// document, preventing it from being linked under the JSON API
+// reserved string `linked`, or `included` in V1 documents.
+func (b *Base) MarkDocument(r reflect.Value) error {
+	var key, err = b.DocumentKey(r)
+
+	if nil == err {
+		b.MarkDocumentKey(key)
 	}
 
-	if _, ok = result.([]interface{}); ok {
-		linked[n] = append(linked[n].([]interface{}), result.([]interface{})...)
-	} else {
-		linked[n] = append(linked[n].([]interface{}), result)
+	return err
+}
+
+// MarkDocumentKey records the resource identified by
+// `k` as present in the document, as MarkDocument does.
+func (b *Base) MarkDocumentKey(k [2]string) {
+	b.LinkedDocuments[k] = nil
+}
+
+// MarkCollection marks the struct elements of the slice or array
+// `i` as present in the document before any is serialized, so
+// primary resources linking each other are not linked under the
+// JSON API reserved string `linked` as well. Elements without an
+// identifier are skipped.
+func (b *Base) MarkCollection(i interface{}) {
+	var value, _, kind, err = Dereference(i)
+
+	if nil != err || (kind != reflect.Slice && kind != reflect.Array) {
+		return
+	}
+
+	for j := 0; j < value.Len(); j++ {
+		var temp = value.Index(j)
+
+		if !temp.CanInterface() {
+			continue
+		}
+
+		var element, typ, kind, err = Dereference(temp.Interface())
+
+		if nil == err && kind == reflect.Struct && !IsMarshaler(typ) {
+			b.MarkDocument(element)
+		}
+	}
+}
+
+// ResolveConflict resolves the serialized document `d` of a
+// resource identified by `k` against the document `e` already
+// present for it, updating `e` in place according to Base's
+// ConflictPolicy.
+func (b *Base) ResolveConflict(k [2]string, e, d map[string]interface{}) error {
+	switch b.ConflictPolicy {
+	case ConflictLastWins:
+		for key := range e {
+			delete(e, key)
+		}
+
+		mergeDocument(e, d)
+	case ConflictMerge:
+		mergeDocument(e, d)
+	case ConflictFail:
+		if !reflect.DeepEqual(e, d) {
+			return DocumentConflictError{k}
+		}
 	}
 
 	return nil
@@ -609,98 +1312,342 @@ func (b *Base) LinkCompoundDocument(v reflect.Value, n string) error {
 
 // LinkStructField attempts to add link details for a value
 // to a map[string]interface{} under the JSON API reserved
-// string `links`. If LinkStructField an error detailing
-// what went wrong is returned.
+// string `links`. Nil pointers are linked as nil. If
+// LinkStructField an error detailing what went wrong is
+// returned.
 func (b *Base) LinkStructField(m map[string]interface{}, p, v reflect.Value, t reflect.Type, k reflect.Kind, f reflect.StructField) error {
 	var (
-		links map[string]interface{}
-		ok    bool
+		field        = b.StructFieldMetadata(p.Type(), f)
+		links        = b.Links(m)
+		details, err = b.linkDetails(p, v, t, k, &field)
 	)
 
-	if links, ok = m[b.ReservedStrings.Links].(map[string]interface{}); !ok {
-		links = make(map[string]interface{})
-		m[b.ReservedStrings.Links] = links
+	if nil != err {
+		return err
+	}
+
+	links[field.Name] = details
+
+	return nil
+}
+
+// linkDetails returns the link details of the field `f` of the
+// struct value `p` for its dereferenced value `v` of the
+// reflect.Type `t` and reflect.Kind `k`, as LinkStructField adds
+// them under the JSON API reserved string `links`, linking
+// compound documents. Nil pointers have nil link details.
+func (b *Base) linkDetails(p, v reflect.Value, t reflect.Type, k reflect.Kind, f *FieldMetadata) (interface{}, error) {
+	if k == reflect.Ptr {
+		return nil, nil
 	}
 
 	var (
-		attr     = b.FormatAttributeName(f.Name)
 		details  = make(map[string]interface{})
-		href     = f.Tag.Get(TranqHref)
-		typ, err = TypeName(t)
-		ids      = make([]interface{}, 0, 0)
+		typ      = b.Metadata(t).Name
+		ids, err = b.linkIdentifiers(v, k, typ, f)
 	)
 
 	if nil != err {
-		return err
+		return nil, err
+	} else if k == reflect.Struct {
+		details[b.ReservedStrings.ID] = ids[0]
+	} else {
+		details[b.ReservedStrings.IDs] = ids
 	}
 
-	typ = b.FormatTypeName(typ)
+	if 0 < len(f.Href) {
+		details[b.ReservedStrings.Href] = b.linkHref(p, f, typ, ids)
+	}
+
+	details[b.ReservedStrings.Type] = typ
+
+	return details, nil
+}
+
+// linkIdentifiers returns the identifiers of the resources linked
+// by the field `f`, its dereferenced value `v` of the reflect.Kind
+// `k` being a struct value or a slice or array of them, linking
+// those that are compound documents of the formatted type `t`
+// if the field is included by Base's IncludePaths.
+func (b *Base) linkIdentifiers(v reflect.Value, k reflect.Kind, t string, f *FieldMetadata) ([]interface{}, error) {
+	var (
+		ids               = make([]interface{}, 0, 0)
+		include, included = b.IncludePaths.Relationship(f.Name)
+		parent            = b.IncludePaths
+	)
+
+	b.IncludePaths = include
+	defer func() { b.IncludePaths = parent }()
 
 	if k == reflect.Struct {
-		var id = v.FieldByName(ID)
+		var id, ok = b.Identifier(v)
 
-		if !id.IsValid() {
-			return MissingIdentifierError{v}
+		if !ok {
+			return nil, MissingIdentifierError{v, b.IdentifierStrategy}
 		}
 
-		ids = append(ids, id.Interface())
-		details[b.ReservedStrings.ID] = id.Interface()
+		ids = append(ids, id)
 
-		if b.IsCompoundDocument(v) {
-			if err = b.LinkCompoundDocument(v, typ); nil != err {
-				return err
+		if included && b.IsCompoundDocument(v) {
+			if err := b.LinkCompoundDocument(v, t); nil != err {
+				return nil, err
 			}
 		}
 
-	} else if k == reflect.Slice || k == reflect.Array {
-		for i := 0; i < v.Len(); i++ {
-			var temp = v.Index(i)
-			if !temp.CanInterface() {
-				return UninterfaceableValueError{temp}
-			}
+		return ids, nil
+	}
 
-			var element, _, _, err = Dereference(temp.Interface())
+	for i := 0; i < v.Len(); i++ {
+		var temp = v.Index(i)
+		if !temp.CanInterface() {
+			return nil, UninterfaceableValueError{temp}
+		}
 
-			if nil != err {
-				return err
-			}
+		var element, _, kind, err = Dereference(temp.Interface())
 
-			var id = element.FieldByName(ID)
+		if nil != err {
+			return nil, err
+		} else if kind == reflect.Ptr {
+			continue
+		}
 
-			if !id.IsValid() {
-				return MissingIdentifierError{v}
-			}
+		var id, ok = b.Identifier(element)
 
-			ids = append(ids, id.Interface())
+		if !ok {
+			return nil, MissingIdentifierError{v, b.IdentifierStrategy}
+		}
 
-			if b.IsCompoundDocument(element) {
-				if err = b.LinkCompoundDocument(element, typ); nil != err {
-					return err
-				}
+		ids = append(ids, id)
+
+		if included && b.IsCompoundDocument(element) {
+			if err = b.LinkCompoundDocument(element, t); nil != err {
+				return nil, err
 			}
 		}
+	}
 
-		details[b.ReservedStrings.IDs] = ids
+	return ids, nil
+}
+
+// linkHref returns the formatted `tranq_href` struct tag of the
+// field `f` of the struct value `p`, linking resources of the
+// formatted type `t` with the identifiers `ids`, adding its URL
+// template to the document if Base's LinkTemplates is set.
+func (b *Base) linkHref(p reflect.Value, f *FieldMetadata, t string, ids []interface{}) string {
+	var (
+		parent = b.Metadata(p.Type()).Name
+		id, _  = b.Identifier(p)
+		href   = b.FormatOwnerHref(f.Href, parent, id, t, ids)
+	)
+
+	if b.LinkTemplates {
+		b.LinkTemplate(parent, f.Name, t, f.Href)
 	}
 
-	if 0 < len(href) {
-		var parent string
+	return href
+}
 
-		if parent, err = TypeName(p.Type()); nil != err {
-			return err
-		}
+// Links returns the object stored under the JSON API
+// reserved string `links` of the serialized document
+// `m`, adding an empty one if it has none.
+func (b *Base) Links(m map[string]interface{}) map[string]interface{} {
+	var links, ok = m[b.ReservedStrings.Links].(map[string]interface{})
 
-		parent = b.FormatTypeName(parent)
-		details[b.ReservedStrings.Href] = b.FormatHref(href, parent, typ, ids)
+	if !ok {
+		links = make(map[string]interface{})
+		m[b.ReservedStrings.Links] = links
 	}
 
-	details[b.ReservedStrings.Type] = typ
+	return links
+}
 
-	links[attr] = details
+// DocumentKey returns the key uniquely identifying the struct
+// value `v` within a document, its formatted type name paired
+// with its identifier.
+func (b *Base) DocumentKey(v reflect.Value) ([2]string, error) {
+	var (
+		metadata = b.Metadata(v.Type())
+		id, ok   = metadata.identifier(v)
+	)
+
+	if !ok {
+		return [2]string{}, MissingIdentifierError{v, b.IdentifierStrategy}
+	}
+
+	return [2]string{metadata.Name, fmt.Sprint(id)}, nil
+}
+
+// EnterDocument appends the struct value `v` to Base's Path.
+// If `v` is already present along the Path a CycleError naming
+// the Path is returned, if the Path has reached Base's MaxDepth
+// a DepthError is.
+func (b *Base) EnterDocument(v reflect.Value) error {
+	var key, err = b.DocumentKey(v)
+
+	if nil != err {
+		return err
+	}
+
+	return b.EnterDocumentKey(key)
+}
+
+// EnterDocumentKey appends the resource identified by `k`
+// to Base's Path, as EnterDocument does.
+func (b *Base) EnterDocumentKey(k [2]string) error {
+	if b.IsVisitingKey(k) || b.IsMaxDepth() {
+		var path = make([][2]string, len(b.Path), len(b.Path)+1)
+		copy(path, b.Path)
+
+		if b.IsVisitingKey(k) {
+			return CycleError{append(path, k)}
+		}
+
+		return DepthError{append(path, k), b.Depth()}
+	}
+
+	b.Path = append(b.Path, k)
 
 	return nil
 }
 
+// Depth returns Base's MaxDepth, or
+// DefaultMaxDepth if it is not set.
+func (b *Base) Depth() int {
+	if 0 == b.MaxDepth {
+		return DefaultMaxDepth
+	}
+
+	return b.MaxDepth
+}
+
+// IsMaxDepth reports whether Base's Path
+// has reached its maximum Depth.
+func (b *Base) IsMaxDepth() bool {
+	return b.Depth() <= len(b.Path)
+}
+
+// LeaveDocument removes the most recently entered
+// resource from Base's Path.
+func (b *Base) LeaveDocument() {
+	if 0 < len(b.Path) {
+		b.Path = b.Path[:len(b.Path)-1]
+	}
+}
+
+// IsVisiting reports whether the struct value `v` is present
+// along Base's Path, that is whether it is currently being
+// serialized by one of the resources linking to it.
+func (b *Base) IsVisiting(v reflect.Value) bool {
+	var key, err = b.DocumentKey(v)
+
+	if nil != err {
+		return false
+	}
+
+	return b.IsVisitingKey(key)
+}
+
+// IsVisitingKey reports whether the resource identified
+// by `k` is present along Base's Path.
+func (b *Base) IsVisitingKey(k [2]string) bool {
+	for i := 0; i < len(b.Path); i++ {
+		if k == b.Path[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fieldset returns the sparse fieldset for resources of the
+// struct type `t`, reporting false if every attribute is to be
+// serialized. If Base's StrictFields is set, an UnknownFieldError
+// is returned for attribute names `t` does not have.
+func (b *Base) Fieldset(t reflect.Type) (map[string]struct{}, bool, error) {
+	if nil == b.Fieldsets {
+		return nil, false, nil
+	}
+
+	var (
+		metadata     = b.Metadata(t)
+		fieldset, ok = b.Fieldsets[metadata.Name]
+	)
+
+	if !ok {
+		return nil, false, nil
+	} else if !b.StrictFields {
+		return fieldset, true, nil
+	} else if err := CheckFieldset(metadata.Name, fieldset, metadata.Names); nil != err {
+		return nil, false, err
+	}
+
+	return fieldset, true, nil
+}
+
+// Identifier returns the identifier of the struct value `v`
+// located by Base's IdentifierStrategy, reporting false if
+// it has none.
+func (b *Base) Identifier(v reflect.Value) (interface{}, bool) {
+	if v.Kind() != reflect.Struct {
+		return b.identifierStrategy().Identifier(v)
+	}
+
+	return b.Metadata(v.Type()).identifier(v)
+}
+
+// IdentifierField returns the struct field of type `t`
+// containing the identifier located by Base's
+// IdentifierStrategy, reporting false if there is none.
+func (b *Base) IdentifierField(t reflect.Type) (reflect.StructField, bool) {
+	if t.Kind() != reflect.Struct {
+		return b.identifierStrategy().IdentifierField(t)
+	}
+
+	var metadata = b.Metadata(t)
+
+	return metadata.IdentifierField, metadata.Identified
+}
+
+// IdentifierName returns the attribute name the identifier
+// of values of type `t` is serialized under, the JSON API
+// reserved string `id` if it has been set, otherwise the
+// attribute name of its identifier field.
+func (b *Base) IdentifierName(t reflect.Type) string {
+	if 0 < len(b.ReservedStrings.ID) {
+		return b.ReservedStrings.ID
+	} else if t.Kind() == reflect.Struct {
+		return b.Metadata(t).IdentifierName
+	} else if field, ok := b.IdentifierField(t); ok {
+		return b.AttributeName(field, b.Tag(field))
+	}
+
+	return b.FormatAttributeName(ID)
+}
+
+// Tag returns the parsed Tag of the struct field `f`, read
+// from its `tranq` struct tag, or from its `json` struct tag
+// if Base's UseJSONTags is set and no `tranq` struct tag is
+// present.
+func (b *Base) Tag(f reflect.StructField) Tag {
+	if _, ok := f.Tag.Lookup(Tranq); !ok && b.UseJSONTags {
+		return ParseJSONTag(f)
+	}
+
+	return ParseTag(f)
+}
+
+// AttributeName returns the attribute name of the struct
+// field `f`, the name provided by its parsed Tag `t` if
+// present, otherwise its name formatted by Base's
+// AttributeNameFormatter NamingFormatter.
+func (b *Base) AttributeName(f reflect.StructField, t Tag) string {
+	if 0 < len(t.Name) {
+		return t.Name
+	}
+
+	return b.FormatAttributeName(f.Name)
+}
+
 // FormatAttributeName allows access to Base's
 // AttributeNameFormatter NameFormatter. If no
 // AttributeNameFormatter was provided, the original
@@ -726,13 +1673,502 @@ func (b *Base) FormatTypeName(s string) string {
 }
 
 // FormatHref allows access to Base's HrefFormatter
-// HrefFormatter. If no FormatHref was provided, the
-// original `href` string is returned in place of a
-// formatted one.
+// HrefFormatter, through the ContextHrefFormatter
+// interface if it is implemented. If no FormatHref was
+// provided, the original `href` string is returned in
+// place of a formatted one.
 func (b *Base) FormatHref(h, o, c string, i []interface{}) string {
-	if nil == b.HrefFormatter {
+	if formatter, ok := b.HrefFormatter.(ContextHrefFormatter); ok {
+		return formatter.FormatHrefContext(b.Context(), h, o, nil, c, i)
+	} else if nil == b.HrefFormatter {
 		return h
 	}
 
 	return b.HrefFormatter.FormatHref(h, o, c, i)
 }
+
+// FormatOwnerHref formats the href `h` of resources of type `c`
+// with the identifiers `i`, linked from the resource of type `o`
+// with the identifier `id`, using Base's HrefFormatter if it
+// implements the ContextHrefFormatter or OwnerHrefFormatter
+// interface and FormatHref otherwise.
+func (b *Base) FormatOwnerHref(h, o string, id interface{}, c string, i []interface{}) string {
+	if formatter, ok := b.HrefFormatter.(ContextHrefFormatter); ok {
+		return formatter.FormatHrefContext(b.Context(), h, o, id, c, i)
+	} else if formatter, ok := b.HrefFormatter.(OwnerHrefFormatter); ok {
+		return formatter.FormatOwnerHref(h, o, id, c, i)
+	}
+
+	return b.FormatHref(h, o, c, i)
+}
+
+// streamValue writes the value `i` to the Encoder `e` as Serialize
+// serializes it, writing structs member by member and the elements
+// of slices and arrays in turn. Values of other kinds are
+// serialized before being written.
+func (b *Base) streamValue(e *Encoder, i interface{}) error {
+	var value, typ, kind, err = Dereference(i)
+
+	if nil == err && value.IsValid() && value.CanInterface() && kind != reflect.Ptr && !IsMarshaler(typ) {
+		switch kind {
+		case reflect.Struct:
+			return b.streamStruct(e, value)
+		case reflect.Array, reflect.Slice:
+			return b.streamArray(e, value)
+		}
+	}
+
+	var result interface{}
+
+	if result, err = b.Serialize(i); nil != err {
+		return err
+	}
+
+	return e.WriteValue(result)
+}
+
+// streamArray writes the elements of the slice or array
+// value `v` to the Encoder `e` as SerializeArray
+// serializes them, each with streamValue.
+func (b *Base) streamArray(e *Encoder, v reflect.Value) error {
+	if err := e.WriteRaw("["); nil != err {
+		return err
+	}
+
+	for j := 0; j < v.Len(); j++ {
+		var element = v.Index(j)
+
+		if !element.CanInterface() {
+			return UninterfaceableValueError{element}
+		} else if 0 < j {
+			if err := e.WriteRaw(","); nil != err {
+				return err
+			}
+		}
+
+		if err := b.streamValue(e, element.Interface()); nil != err {
+			return err
+		}
+	}
+
+	return e.WriteRaw("]")
+}
+
+// streamStruct writes the struct value `v` to the Encoder `e` as
+// SerializeStruct serializes it, member by member. Values of types
+// whose attribute names are not unique, are formatted alike the
+// members named by JSON API reserved strings or whose link fields
+// are not declared in the order of their names are built by
+// SerializeStruct first.
+func (b *Base) streamStruct(e *Encoder, v reflect.Value) error {
+	if err := b.Err(); nil != err {
+		return err
+	}
+
+	var (
+		t              = v.Type()
+		metadata       = b.Metadata(t)
+		id, identified = metadata.identifier(v)
+		keys, n, ok    = b.documentMembers(t, metadata, identified)
+		err            error
+	)
+
+	if !ok {
+		var document interface{}
+
+		if document, err = b.SerializeStruct(v); nil != err {
+			return err
+		}
+
+		return e.WriteValue(document)
+	}
+
+	if identified {
+		if err = b.EnterDocument(v); nil != err {
+			return err
+		}
+
+		defer b.LeaveDocument()
+	}
+
+	var (
+		fieldset map[string]struct{}
+		sparse   bool
+		written  = 0
+		next     = 0
+	)
+
+	if fieldset, sparse, err = b.Fieldset(t); nil != err {
+		return err
+	} else if err = e.WriteRaw("{"); nil != err {
+		return err
+	}
+
+	for j := 0; j <= len(metadata.sorted) && nil == err; j++ {
+		var field *FieldMetadata
+
+		if j < len(metadata.sorted) {
+			field = &metadata.Fields[metadata.sorted[j]]
+		}
+
+		for ; next < n && nil == err && (nil == field || keys[next] < field.Name); next++ {
+			switch keys[next] {
+			case b.ReservedStrings.Links:
+				err = b.streamLinks(e, &written, v, metadata, fieldset, sparse)
+			case b.ReservedStrings.Meta:
+				var meta map[string]interface{}
+
+				if meta, err = b.ResourceMeta(v); nil == err && 0 < len(meta) {
+					if err = e.writeMember(&written, keys[next]); nil == err {
+						err = e.WriteValue(meta)
+					}
+				}
+			default:
+				var result interface{}
+
+				if result, err = b.Serialize(id); nil == err {
+					if err = e.writeMember(&written, keys[next]); nil == err {
+						err = e.WriteValue(result)
+					}
+				}
+			}
+		}
+
+		if nil != field && nil == err {
+			err = b.streamAttribute(e, &written, v, field, fieldset, sparse)
+		}
+	}
+
+	if nil != err {
+		return err
+	}
+
+	return e.WriteRaw("}")
+}
+
+// documentMembers returns the sorted names of the members of the
+// documents of struct type `t` that are not attributes, the JSON
+// API reserved strings `links` and `meta` along with the name of
+// its identifier if `i` is true, and their number. It reports false
+// if documents of the type cannot be written member by member.
+func (b *Base) documentMembers(t reflect.Type, m *TypeMetadata, i bool) ([3]string, int, bool) {
+	var (
+		keys = [3]string{b.ReservedStrings.Links, b.ReservedStrings.Meta}
+		n    = 2
+	)
+
+	if i {
+		keys[n] = b.IdentifierName(t)
+		n++
+	}
+
+	if !sortMembers(keys[:n]) {
+		return keys, n, false
+	}
+
+	for j := 0; j < n; j++ {
+		if _, ok := m.Names[keys[j]]; ok {
+			return keys, n, false
+		}
+	}
+
+	if _, ok := m.Names[b.ReservedStrings.Self]; ok && m.HasSelf {
+		return keys, n, false
+	}
+
+	return keys, n, m.streamable
+}
+
+// streamAttribute writes the field `f` of the struct value `r` as a
+// member of the document with `n` members written, unless it is left
+// out by the sparse fieldset `s`, omitted as empty or linked.
+func (b *Base) streamAttribute(e *Encoder, n *int, r reflect.Value, f *FieldMetadata, s map[string]struct{}, sparse bool) error {
+	var val, _, _, role, err = b.classifyField(r, f, s, sparse)
+
+	if nil != err || fieldOmitted == role || fieldLinked == role {
+		return err
+	} else if err = e.writeMember(n, f.Name); nil != err {
+		return err
+	}
+
+	return b.streamAttributeValue(e, val, role)
+}
+
+// streamAttributeValue writes the value `v` of a field classified
+// as taking the role `role` to the Encoder `e`, as attributeValue
+// serializes it.
+func (b *Base) streamAttributeValue(e *Encoder, v reflect.Value, role fieldRole) error {
+	if fieldAttribute == role {
+		if leaf, err := e.writeLeaf(v); leaf || nil != err {
+			return err
+		}
+	}
+
+	var value, err = b.attributeValue(v, role)
+
+	if nil != err {
+		return err
+	}
+
+	return e.WriteValue(value)
+}
+
+// streamLinks writes the link details of the linked fields of the
+// struct value `r` by attribute name, along with its self link,
+// as the member `links` of the document with `n` members written,
+// unless it has none. Compound documents are linked in the
+// order of the fields, as SerializeStruct links them.
+func (b *Base) streamLinks(e *Encoder, n *int, r reflect.Value, m *TypeMetadata, s map[string]struct{}, sparse bool) error {
+	var (
+		self, pending, err = b.SelfLink(r)
+		written            = 0
+	)
+
+	if nil != err {
+		return err
+	}
+
+	for j := 0; j <= len(m.sorted); j++ {
+		var field *FieldMetadata
+
+		if j < len(m.sorted) {
+			field = &m.Fields[m.sorted[j]]
+		}
+
+		if pending && (nil == field || b.ReservedStrings.Self < field.Name) {
+			if err = b.streamLinksMember(e, n, &written, b.ReservedStrings.Self); nil != err {
+				return err
+			} else if err = e.writeString(self); nil != err {
+				return err
+			}
+
+			pending = false
+		}
+
+		if nil == field || !field.Link {
+			continue
+		}
+
+		var val, ftyp, kind, role, err = b.classifyField(r, field, s, sparse)
+
+		if nil != err {
+			return err
+		} else if fieldLinked != role {
+			continue
+		} else if err = b.streamLinksMember(e, n, &written, field.Name); nil != err {
+			return err
+		} else if err = b.streamLinkDetails(e, r, val, ftyp, kind, field); nil != err {
+			return err
+		}
+	}
+
+	if 0 < written {
+		return e.WriteRaw("}")
+	}
+
+	return nil
+}
+
+// streamLinkDetails writes the link details of the field `f` of
+// the struct value `p` to the Encoder `e` as linkDetails returns
+// them, member by member, for its dereferenced value `v` of the
+// reflect.Type `t` and reflect.Kind `k`. If two of the reserved
+// strings naming their members are formatted alike, they are
+// built by linkDetails first.
+func (b *Base) streamLinkDetails(e *Encoder, p, v reflect.Value, t reflect.Type, k reflect.Kind, f *FieldMetadata) error {
+	var keys, n, ok = b.linkMembers(k, f)
+
+	if k == reflect.Ptr || !ok {
+		var details, err = b.linkDetails(p, v, t, k, f)
+
+		if nil != err {
+			return err
+		}
+
+		return e.WriteValue(details)
+	}
+
+	var (
+		typ      = b.Metadata(t).Name
+		ids, err = b.linkIdentifiers(v, k, typ, f)
+		written  = 0
+	)
+
+	if nil != err {
+		return err
+	} else if err = e.WriteRaw("{"); nil != err {
+		return err
+	}
+
+	for j := 0; j < n; j++ {
+		if err = e.writeMember(&written, keys[j]); nil != err {
+			return err
+		}
+
+		switch {
+		case keys[j] == b.ReservedStrings.Type:
+			err = e.writeString(typ)
+		case 0 < len(f.Href) && keys[j] == b.ReservedStrings.Href:
+			err = e.writeString(b.linkHref(p, f, typ, ids))
+		case k == reflect.Struct:
+			err = e.WriteValue(ids[0])
+		default:
+			err = e.WriteValue(ids)
+		}
+
+		if nil != err {
+			return err
+		}
+	}
+
+	return e.WriteRaw("}")
+}
+
+// linkMembers returns the sorted names of the members of the link
+// details of the field `f` for a value of the reflect.Kind `k`,
+// and their number, reporting false if two are formatted alike.
+func (b *Base) linkMembers(k reflect.Kind, f *FieldMetadata) ([3]string, int, bool) {
+	var (
+		keys = [3]string{b.ReservedStrings.Type, b.ReservedStrings.IDs}
+		n    = 2
+	)
+
+	if k == reflect.Struct {
+		keys[1] = b.ReservedStrings.ID
+	}
+
+	if 0 < len(f.Href) {
+		keys[n] = b.ReservedStrings.Href
+		n++
+	}
+
+	return keys, n, sortMembers(keys[:n])
+}
+
+// streamLinksMember writes the member name `k` of the object stored
+// under the JSON API reserved string `links` with `w` members
+// written, opening the object as a member of the document with
+// `n` members written first if `k` is its first member.
+func (b *Base) streamLinksMember(e *Encoder, n, w *int, k string) error {
+	if 0 == *w {
+		if err := e.writeMember(n, b.ReservedStrings.Links); nil != err {
+			return err
+		} else if err = e.WriteRaw("{"); nil != err {
+			return err
+		}
+	}
+
+	return e.writeMember(w, k)
+}
+
+// fieldValue returns the dereferenced value of the field `f` of
+// the struct value `r`, reporting false if it is left out of the
+// resource object by the sparse fieldset `s` or as an empty value.
+// Fields of kinds other than pointers and interfaces are returned
+// without being interfaced.
+func (b *Base) fieldValue(r reflect.Value, f *FieldMetadata, s map[string]struct{}, sparse bool) (reflect.Value, reflect.Type, reflect.Kind, bool, error) {
+	var temp = r.Field(f.Field.Index[0])
+
+	if _, ok := s[f.Name]; sparse && !ok {
+		return reflect.Value{}, nil, reflect.Invalid, false, nil
+	} else if !temp.CanInterface() {
+		return reflect.Value{}, nil, reflect.Invalid, false, UninterfaceableValueError{temp}
+	} else if b.IsOmitted(f.Tag, temp) {
+		return reflect.Value{}, nil, reflect.Invalid, false, nil
+	} else if kind := temp.Kind(); kind != reflect.Ptr && kind != reflect.Interface {
+		return temp, temp.Type(), kind, true, nil
+	}
+
+	var val, typ, kind, err = Dereference(temp.Interface())
+
+	return val, typ, kind, nil == err, err
+}
+
+// fieldRole is the part a field of a resource takes in its
+// document, as decided by classifyField.
+type fieldRole int
+
+const (
+	// fieldOmitted fields are left out of the document.
+	fieldOmitted fieldRole = iota
+	// fieldAttribute fields are serialized by Serialize.
+	fieldAttribute
+	// fieldMarshaler fields are serialized by SerializeMarshaler.
+	fieldMarshaler
+	// fieldNull fields, nil interfaces, are serialized as nil.
+	fieldNull
+	// fieldLinked fields are linked resources.
+	fieldLinked
+)
+
+// classifyField returns the dereferenced value of the field `f` of
+// the struct value `r`, its reflect.Type and reflect.Kind, and the
+// role it takes in the document, deciding for SerializeStruct,
+// SerializeResource and the Encoder alike. Fields left out by the
+// sparse fieldset `s` or as empty values are fieldOmitted, and
+// resources in fields that are not linked fail with an
+// UnlinkedResourceError.
+func (b *Base) classifyField(r reflect.Value, f *FieldMetadata, s map[string]struct{}, sparse bool) (reflect.Value, reflect.Type, reflect.Kind, fieldRole, error) {
+	var val, typ, kind, ok, err = b.fieldValue(r, f, s, sparse)
+
+	if nil != err || !ok {
+		return val, typ, kind, fieldOmitted, err
+	}
+
+	var role = classifyValue(typ, kind, f.Link)
+
+	if fieldLinked == role && !f.Link {
+		return val, typ, kind, fieldOmitted, UnlinkedResourceError{r}
+	}
+
+	return val, typ, kind, role, nil
+}
+
+// classifyValue returns the role taken by a dereferenced value of
+// the reflect.Type `t` and reflect.Kind `k`, in a field linked if
+// `l` is true. Resources are fieldLinked whether or not the field
+// is linked, leaving the caller to reject those that are not.
+func classifyValue(t reflect.Type, k reflect.Kind, l bool) fieldRole {
+	switch {
+	case k == reflect.Invalid:
+		return fieldNull
+	case k != reflect.Ptr && IsMarshaler(t):
+		return fieldMarshaler
+	case k == reflect.Struct || k == reflect.Array || k == reflect.Slice || (l && k == reflect.Ptr):
+		return fieldLinked
+	}
+
+	return fieldAttribute
+}
+
+// attributeValue serializes the value `v` of a field
+// classified as taking the role `role`, other than
+// fieldOmitted and fieldLinked.
+func (b *Base) attributeValue(v reflect.Value, role fieldRole) (interface{}, error) {
+	switch role {
+	case fieldNull:
+		return nil, nil
+	case fieldMarshaler:
+		return b.SerializeMarshaler(v)
+	}
+
+	return b.Serialize(v.Interface())
+}
+
+// sortMembers sorts the member names `k` in place, reporting
+// whether they are unique.
+func sortMembers(k []string) bool {
+	for i := 1; i < len(k); i++ {
+		for j := i; 0 < j && k[j] < k[j-1]; j-- {
+			k[j], k[j-1] = k[j-1], k[j]
+		}
+	}
+
+	for i := 1; i < len(k); i++ {
+		if k[i] == k[i-1] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,9 +1,15 @@
 package serializers_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"net"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 import (
@@ -42,14 +48,70 @@ func TestUnlinkedResourceError(t *testing.T) {
 	assert.Equal(t, str, err.Error(), "failed to return correct error message for UnlinkedResourceError")
 }
 
+type TStrategy struct {
+	serializers.FieldIdentifier
+}
+
 func TestMissingIdentifierError(t *testing.T) {
 	var (
 		val = reflect.ValueOf(1)
-		err = serializers.MissingIdentifierError{val}
+		err = serializers.MissingIdentifierError{Value: val}
 		str = fmt.Sprintf("value `%s` is missing identifier field `%s`", val, serializers.ID)
 	)
 
 	assert.Equal(t, str, err.Error(), "failed to return correct error message for MissingIdentifierError")
+
+	for strategy, description := range map[serializers.IdentifierStrategy]string{
+		serializers.FieldIdentifier("Key"):                                        "field `Key`",
+		serializers.TagIdentifier(serializers.Tranq):                              "field tagged `tranq:\"id\"`",
+		serializers.MethodIdentifier{}:                                            "method `Identifier`",
+		serializers.MethodIdentifier{Fallback: serializers.TagIdentifier("json")}: "method `Identifier` or field tagged `json:\"id\"`",
+		TStrategy{serializers.FieldIdentifier("Key")}:                             "located by `serializers_test.TStrategy`",
+	} {
+		err = serializers.MissingIdentifierError{Value: val, Strategy: strategy}
+
+		assert.Equal(t, fmt.Sprintf("value `%s` is missing identifier %s", val, description), err.Error(), "failed to name where IdentifierStrategy locates identifiers")
+	}
+}
+
+func TestUnsupportedMapKeyError(t *testing.T) {
+	var (
+		typ = reflect.TypeOf(1.5)
+		err = serializers.UnsupportedMapKeyError{typ}
+		str = fmt.Sprintf("map key type `%s` must be a string, integer or implement encoding.TextMarshaler", typ)
+	)
+
+	assert.Equal(t, str, err.Error(), "failed to return correct error message for UnsupportedMapKeyError")
+}
+
+func TestCycleError(t *testing.T) {
+	var (
+		path = [][2]string{{"persons", "1"}, {"persons", "2"}, {"persons", "1"}}
+		err  = serializers.CycleError{path}
+		str  = "unable to resolve cycle in linked resources along path `persons:1 -> persons:2 -> persons:1`"
+	)
+
+	assert.Equal(t, str, err.Error(), "failed to return correct error message for CycleError")
+}
+
+func TestDepthError(t *testing.T) {
+	var (
+		path = [][2]string{{"persons", "1"}, {"persons", "2"}, {"persons", "3"}}
+		err  = serializers.DepthError{path, 2}
+		str  = "resources nested along path `persons:1 -> persons:2 -> persons:3` exceed the maximum depth of 2"
+	)
+
+	assert.Equal(t, str, err.Error(), "failed to return correct error message for DepthError")
+}
+
+func TestNestingError(t *testing.T) {
+	var (
+		typ = reflect.TypeOf(map[string]interface{}{})
+		err = serializers.NestingError{typ, 2}
+		str = "map of type `map[string]interface {}` is nested beyond the maximum depth of 2"
+	)
+
+	assert.Equal(t, str, err.Error(), "failed to return correct error message for NestingError")
 }
 
 func TestHrefFormatterFuncImplementation(t *testing.T) {
@@ -92,6 +154,18 @@ func TestDereference(t *testing.T) {
 	assert.Equal(t, tb.String(), "int", "failed to dereference %T into correct type", b)
 	assert.Equal(t, tc.String(), "int", "failed to dereference %T into correct type", c)
 }
+func TestDereferenceNil(t *testing.T) {
+	var (
+		a            *int
+		v, typ, k, e = serializers.Dereference(a)
+	)
+
+	assert.Nil(t, e, "dereferencing of nil %T resulted in an error", a)
+	assert.Equal(t, reflect.Ptr, k, "failed to stop dereferencing at nil %T", a)
+	assert.True(t, v.IsNil(), "failed to return nil %T", a)
+	assert.Equal(t, "*int", typ.String(), "failed to return type of nil %T", a)
+}
+
 func TestTypeName(t *testing.T) {
 	var (
 		a = 1
@@ -121,6 +195,25 @@ func TestAccept(t *testing.T) {
 	assert.NotNil(t, result["int"], "failed to set root level namespace")
 }
 
+func TestAcceptMap(t *testing.T) {
+	var (
+		buffer      bytes.Buffer
+		result, err = serializer.Accept(map[string]int{"a": 1})
+	)
+
+	assert.Nil(t, result, "returned document for map")
+	assert.Equal(t, serializers.UnsupportedKindError{reflect.Map, &serializer}, err, "failed to return serializers.UnsupportedKindError for map")
+
+	_, err = serializer.Accept([]*map[string]int{{"a": 1}})
+
+	assert.IsType(t, serializers.UnsupportedKindError{}, err, "failed to return serializers.UnsupportedKindError for collection of maps")
+
+	err = serializers.NewEncoder(&buffer, &serializer).Encode(map[string]int{"a": 1})
+
+	assert.IsType(t, serializers.UnsupportedKindError{}, err, "failed to return serializers.UnsupportedKindError from Encode")
+	assert.Empty(t, buffer.String(), "encoded document for map")
+}
+
 func TestSerialize(t *testing.T) {}
 
 func TestSerializeInvalid(t *testing.T) {
@@ -128,6 +221,13 @@ func TestSerializeInvalid(t *testing.T) {
 
 	assert.NotNil(t, err, "failed to return serializers.UnsupportedKindError from SerializeInvalid")
 	assert.IsType(t, err, serializers.UnsupportedKindError{}, "error was not type of serializers.UnsupportedKindError")
+
+	var result interface{}
+
+	result, err = serializer.SerializeInvalid(reflect.Value{})
+
+	assert.Nil(t, err, "received unexpected error from SerializeInvalid")
+	assert.Nil(t, result, "failed to serialize zero reflect.Value as nil")
 }
 
 func TestSerializeBool(t *testing.T) {
@@ -307,6 +407,74 @@ func TestSerializeMap(t *testing.T) {
 
 	assert.NotNil(t, err, "failed to return serializers.UnsupportedKindError from SerializeMap")
 	assert.IsType(t, err, serializers.UnsupportedKindError{}, "error was not type of serializers.UnsupportedKindError")
+
+	var (
+		value  = map[string]interface{}{"Count": 1, "Nested": map[int]string{2: "two"}}
+		result interface{}
+	)
+
+	result, err = serializer.SerializeMap(reflect.ValueOf(value))
+
+	assert.Nil(t, err, "received unexpected error from SerializeMap")
+	assert.Equal(t, map[string]interface{}{"Count": 1, "Nested": map[string]interface{}{"2": "two"}}, result, "failed to establish correct value for SerializeMap")
+
+	result, err = serializer.SerializeMap(reflect.ValueOf(map[string]int(nil)))
+
+	assert.Nil(t, err, "received unexpected error from SerializeMap")
+	assert.Nil(t, result, "failed to serialize nil map as nil")
+}
+
+func TestSerializeMapNesting(t *testing.T) {
+	type Settings struct {
+		ID     int
+		Values map[string]interface{}
+	}
+
+	var (
+		cyclic     = map[string]interface{}{"name": "cyclic"}
+		serializer = serializers.Base{MaxDepth: 2}
+	)
+
+	cyclic["self"] = []interface{}{cyclic}
+
+	var _, err = serializer.Accept(Settings{1, map[string]interface{}{"nested": map[string]int{"a": 1}}})
+
+	assert.Nil(t, err, "received unexpected error from Accept of maps within MaxDepth")
+
+	_, err = serializer.Accept(Settings{1, cyclic})
+
+	assert.Equal(t, serializers.NestingError{reflect.TypeOf(cyclic), 2}, err, "failed to stop serializing self-referencing map")
+}
+
+func TestSerializeMapFormatKeys(t *testing.T) {
+	var serializer = serializers.Base{
+		AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+		FormatMapKeys:          true,
+	}
+
+	var result, err = serializer.SerializeMap(reflect.ValueOf(map[string]int{"Count": 1}))
+
+	assert.Nil(t, err, "received unexpected error from SerializeMap")
+	assert.Equal(t, map[string]interface{}{"count": 1}, result, "failed to format map keys with supplied AttributeNameFormatter")
+}
+
+type TKey struct {
+	A, B int
+}
+
+func (k TKey) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d-%d", k.A, k.B)), nil
+}
+
+func TestSerializeMapKey(t *testing.T) {
+	var (
+		text, terr = serializer.SerializeMapKey(reflect.ValueOf(TKey{1, 2}))
+		_, ferr    = serializer.SerializeMapKey(reflect.ValueOf(1.5))
+	)
+
+	assert.Nil(t, terr, "received unexpected error from SerializeMapKey")
+	assert.Equal(t, "1-2", text, "failed to use encoding.TextMarshaler for map key")
+	assert.IsType(t, serializers.UnsupportedMapKeyError{}, ferr, "error was not type of serializers.UnsupportedMapKeyError")
 }
 
 func TestSerializePtr(t *testing.T) {
@@ -314,6 +482,58 @@ func TestSerializePtr(t *testing.T) {
 
 	assert.NotNil(t, err, "failed to return serializers.UnsupportedKindError from SerializePtr")
 	assert.IsType(t, err, serializers.UnsupportedKindError{}, "error was not type of serializers.UnsupportedKindError")
+
+	var (
+		value  = "string"
+		result interface{}
+	)
+
+	result, err = serializer.SerializePtr(reflect.ValueOf((*string)(nil)))
+
+	assert.Nil(t, err, "received unexpected error from SerializePtr")
+	assert.Nil(t, result, "failed to serialize nil pointer as nil")
+
+	result, err = serializer.SerializePtr(reflect.ValueOf(&value))
+
+	assert.Nil(t, err, "received unexpected error from SerializePtr")
+	assert.Equal(t, value, result, "failed to follow non-nil pointer")
+}
+
+func TestSerializeStructWithPointers(t *testing.T) {
+	type Person struct {
+		ID int
+	}
+
+	type Post struct {
+		ID       int
+		Nickname *string
+		Subtitle *string
+		Author   *Person `tranq_link:"true"`
+		Editor   *Person `tranq_link:"true"`
+	}
+
+	var serializer = serializers.Base{}
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.ID = "id"
+
+	var (
+		nickname    = "nick"
+		result, err = serializer.Accept(Post{1, &nickname, nil, nil, &Person{2}})
+	)
+
+	assert.Nil(t, err, "received unexpected error from SerializeStruct with nil pointers")
+
+	var (
+		post  = result["Post"].(map[string]interface{})
+		links = post["links"].(map[string]interface{})
+	)
+
+	assert.Equal(t, nickname, post["Nickname"], "failed to follow non-nil pointer field")
+	assert.Nil(t, post["Subtitle"], "failed to serialize nil pointer field as nil")
+	assert.Contains(t, links, "Author", "failed to link nil pointer field")
+	assert.Nil(t, links["Author"], "failed to link nil pointer field as nil")
+	assert.Equal(t, 2, links["Editor"].(map[string]interface{})["id"], "failed to link non-nil pointer field")
 }
 
 func TestSerializeSlice(t *testing.T) {
@@ -339,6 +559,25 @@ func TestSerializeString(t *testing.T) {
 	assert.Equal(t, "string", result["string"], "failed to establish correct value for SerializeString")
 }
 
+func TestSerializeStructWithNilInterfaces(t *testing.T) {
+	type Post struct {
+		ID    int
+		Extra interface{}
+		Err   error
+	}
+
+	var serializer = serializers.Base{}
+
+	serializer.ReservedStrings.ID = "id"
+
+	var result, err = serializer.Accept(Post{ID: 1})
+
+	assert.Nil(t, err, "received unexpected error from SerializeStruct with nil interfaces")
+	assert.Equal(t, map[string]interface{}{
+		"Post": map[string]interface{}{"id": 1, "Extra": nil, "Err": nil},
+	}, result, "failed to serialize nil interfaces as nil")
+}
+
 func TestSerializeStruct(t *testing.T) {
 	type Post struct {
 		ID   int
@@ -395,6 +634,116 @@ func TestSerializeStructWithLink(t *testing.T) {
 	assert.Nil(t, err, "returned error from SerializeStruct with embedded linked resource")
 }
 
+type TPtrMarshaler struct {
+	Value string
+}
+
+func (p *TPtrMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Value)
+}
+
+func TestSerializeMarshaler(t *testing.T) {
+	type Post struct {
+		ID        int
+		CreatedAt time.Time
+		DeletedAt *time.Time
+		Address   net.IP
+		Custom    TPtrMarshaler
+	}
+
+	var (
+		created     = time.Date(2014, time.October, 1, 12, 0, 0, 0, time.UTC)
+		address     = net.IPv4(127, 0, 0, 1)
+		result, err = serializer.Accept(Post{1, created, nil, address, TPtrMarshaler{"custom"}})
+	)
+
+	assert.Nil(t, err, "received unexpected error from SerializeStruct with marshaler fields")
+
+	var (
+		post          = result["Post"].(map[string]interface{})
+		encoded, eerr = json.Marshal(post)
+	)
+
+	assert.Equal(t, created, post["CreatedAt"], "failed to serialize time.Time as a leaf value")
+	assert.Nil(t, post["DeletedAt"], "failed to serialize nil *time.Time as nil")
+	assert.Nil(t, eerr, "received unexpected error from json.Marshal")
+	assert.Equal(t, `{"Address":"127.0.0.1","CreatedAt":"2014-10-01T12:00:00Z","Custom":"custom","DeletedAt":null,"ID":1}`, string(encoded), "failed to encode marshaler values with their own methods")
+}
+
+func TestIsMarshaler(t *testing.T) {
+	assert.True(t, serializers.IsMarshaler(reflect.TypeOf(time.Time{})), "failed to detect time.Time as marshaler")
+	assert.True(t, serializers.IsMarshaler(reflect.TypeOf(TPtrMarshaler{})), "failed to detect pointer receiver marshaler")
+	assert.False(t, serializers.IsMarshaler(reflect.TypeOf(1)), "incorrectly detected int as marshaler")
+	assert.False(t, serializers.IsMarshaler(nil), "incorrectly detected nil type as marshaler")
+}
+
+func TestSerializeStructTags(t *testing.T) {
+	type Person struct {
+		ID int
+	}
+
+	type Post struct {
+		ID       int
+		Title    string  `tranq:"heading"`
+		Subtitle string  `tranq:",omitempty"`
+		Secret   string  `tranq:"-"`
+		Hidden   Person  `tranq_ignore:"true"`
+		Author   Person  `tranq:"writer" tranq_link:"true"`
+		Editor   *Person `tranq:",omitempty" tranq_link:"true"`
+		internal string  `tranq:"-"`
+	}
+
+	var serializer = serializers.Base{}
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.ID = "id"
+
+	var result, err = serializer.Accept(Post{1, "Lorem", "", "secret", Person{2}, Person{3}, nil, ""})
+
+	assert.Nil(t, err, "received unexpected error from SerializeStruct with tagged fields")
+
+	var (
+		post  = result["Post"].(map[string]interface{})
+		links = post["links"].(map[string]interface{})
+	)
+
+	assert.Equal(t, "Lorem", post["heading"], "failed to rename attribute with `tranq` struct tag")
+	assert.NotContains(t, post, "Title", "failed to rename attribute with `tranq` struct tag")
+	assert.NotContains(t, post, "Subtitle", "failed to omit empty attribute")
+	assert.NotContains(t, post, "Secret", "failed to ignore attribute")
+	assert.NotContains(t, post, "Hidden", "failed to ignore attribute marked with `tranq_ignore`")
+	assert.Contains(t, links, "writer", "failed to rename link with `tranq` struct tag")
+	assert.NotContains(t, links, "Editor", "failed to omit empty link")
+
+	result, err = serializer.Accept(Post{Subtitle: "Ipsum"})
+
+	assert.Nil(t, err, "received unexpected error from SerializeStruct with tagged fields")
+	assert.Equal(t, "Ipsum", result["Post"].(map[string]interface{})["Subtitle"], "failed to include non-empty attribute marked with omitempty")
+}
+
+func TestSerializeStructJSONTags(t *testing.T) {
+	type Person struct {
+		ID        int    `json:"id"`
+		FirstName string `json:"first_name"`
+		Nickname  string `json:"nickname,omitempty"`
+		Password  string `json:"-"`
+		LastName  string `json:"last_name" tranq:"surname"`
+	}
+
+	var (
+		person     = Person{1, "Jon", "", "secret", "Doe"}
+		serializer = serializers.Base{}
+		result, _  = serializer.Accept(person)
+	)
+
+	assert.Equal(t, map[string]interface{}{"ID": 1, "FirstName": "Jon", "Nickname": "", "Password": "secret", "surname": "Doe"}, result["Person"], "failed to ignore `json` struct tags by default")
+
+	serializer.UseJSONTags = true
+	result, _ = serializer.Accept(person)
+
+	assert.Equal(t, map[string]interface{}{"id": 1, "first_name": "Jon", "surname": "Doe"}, result["Person"], "failed to read `json` struct tags")
+}
+
 func TestSerializeUnsafePointer(t *testing.T) {
 	var _, err = serializer.SerializeUnsafePointer(reflect.ValueOf(1))
 
@@ -402,6 +751,97 @@ func TestSerializeUnsafePointer(t *testing.T) {
 	assert.IsType(t, err, serializers.UnsupportedKindError{}, "error was not type of serializers.UnsupportedKindError")
 }
 
+func TestIsZeroValue(t *testing.T) {
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	var (
+		ptr   *Person
+		now   = time.Now()
+		zeros = []interface{}{
+			false, 0, int8(0), uint(0), 0.0, "", ptr, []int{}, []int(nil),
+			map[string]int{}, [2]int{}, Person{}, time.Time{}, (*time.Time)(nil), nil,
+		}
+		values = []interface{}{
+			true, 1, int8(1), uint(1), 1.5, "a", &Person{}, []int{0},
+			map[string]int{"a": 0}, [2]int{0, 1}, Person{Name: "Jon"}, now, &now,
+		}
+	)
+
+	for _, zero := range zeros {
+		assert.True(t, serializer.IsZeroValue(reflect.ValueOf(zero).Kind(), zero), "failed to detect zero value for %T", zero)
+	}
+
+	for _, value := range values {
+		assert.False(t, serializer.IsZeroValue(reflect.ValueOf(value).Kind(), value), "incorrectly detected zero value for %T", value)
+	}
+}
+
+func TestSerializeNilTimePointer(t *testing.T) {
+	type Person struct {
+		ID        int
+		DeletedAt *time.Time
+	}
+
+	type Post struct {
+		ID        int
+		DeletedAt *time.Time `tranq:",omitempty"`
+		Author    Person     `tranq_link:"true"`
+	}
+
+	var serializer = serializers.Base{}
+
+	serializer.ReservedStrings.Linked = "linked"
+
+	var result, err = serializer.Accept(Post{ID: 1, Author: Person{ID: 2}})
+
+	assert.Nil(t, err, "received unexpected error from Accept with nil *time.Time fields")
+	assert.NotContains(t, result["Post"], "DeletedAt", "failed to omit nil *time.Time attribute")
+	assert.NotContains(t, result, "linked", "failed to link identifier only resource with nil *time.Time field by reference")
+}
+
+func TestIsCompoundDocument(t *testing.T) {
+	type Person struct {
+		ID       int
+		Name     string
+		Internal string `tranq_ignore:"true"`
+	}
+
+	assert.False(t, serializer.IsCompoundDocument(reflect.ValueOf(Person{ID: 1})), "incorrectly detected identifier only value as compound document")
+	assert.False(t, serializer.IsCompoundDocument(reflect.ValueOf(Person{ID: 1, Internal: "x"})), "incorrectly detected ignored fields as compound document")
+	assert.True(t, serializer.IsCompoundDocument(reflect.ValueOf(Person{ID: 1, Name: "Jon"})), "failed to detect compound document")
+	assert.True(t, serializer.IsCompoundDocument(reflect.ValueOf(Person{Name: "Jon"})), "failed to detect compound document without identifier")
+}
+
+func TestLinkStructFieldByReference(t *testing.T) {
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	type Post struct {
+		ID     int
+		Author Person   `tranq_link:"true"`
+		Editor Person   `tranq_link:"true"`
+		Others []Person `tranq_link:"true"`
+	}
+
+	var serializer = serializers.Base{}
+
+	serializer.ReservedStrings.Linked = "linked"
+
+	var result, err = serializer.Accept(Post{1, Person{ID: 1}, Person{2, "Jane"}, []Person{Person{ID: 3}}})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var linked = result["linked"].(map[string]interface{})["Person"].([]interface{})
+
+	assert.Len(t, linked, 1, "failed to link identifier only resources by reference")
+	assert.Equal(t, "Jane", linked[0].(map[string]interface{})["Name"], "failed to link compound document")
+}
+
 func TestLinkStructFieldSingleResource(t *testing.T) {
 	type Person struct {
 		ID int
@@ -541,3 +981,164 @@ func TestFormatHref(t *testing.T) {
 	serializer.HrefFormatter = nil
 	assert.Equal(t, serializer.FormatHref(test, "", "", []interface{}{}), test, "failed to return default value when no HrefFormatter supplied")
 }
+
+type Friend struct {
+	ID     int
+	Name   string
+	Friend *Friend `tranq_link:"true"`
+}
+
+func TestSerializeCyclicLinks(t *testing.T) {
+	var (
+		jon        = &Friend{ID: 1, Name: "Jon"}
+		jane       = &Friend{ID: 2, Name: "Jane", Friend: jon}
+		serializer = serializers.Base{}
+	)
+
+	jon.Friend = jane
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+	serializer.ReservedStrings.ID = "id"
+
+	var result, err = serializer.Accept(jon)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var linked = result["linked"].(map[string]interface{})["Friend"].([]interface{})
+
+	assert.Len(t, linked, 1, "failed to stop linking resources already along the path")
+	assert.Equal(t, 2, linked[0].(map[string]interface{})["id"], "failed to link compound document")
+	assert.Empty(t, serializer.Path, "failed to leave resources after serialization")
+}
+
+func TestSerializeMaxDepth(t *testing.T) {
+	var (
+		root       = &Friend{ID: 1}
+		last       = root
+		serializer = serializers.Base{MaxDepth: 3}
+	)
+
+	for i := 2; i <= 5; i++ {
+		last.Friend = &Friend{ID: i, Name: fmt.Sprint(i)}
+		last = last.Friend
+	}
+
+	serializer.ReservedStrings.ID = "id"
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+
+	var result, err = serializer.Accept(root)
+
+	if !assert.Nil(t, err, "received unexpected error from Accept of path deeper than MaxDepth") {
+		return
+	}
+
+	var linked = result["linked"].(map[string]interface{})["Friend"].([]interface{})
+
+	assert.Len(t, linked, 2, "failed to stop linking resources at MaxDepth")
+	assert.Equal(t, 3, linked[0].(map[string]interface{})["id"], "failed to link resources up to MaxDepth")
+	assert.Equal(t, 4, linked[0].(map[string]interface{})["links"].(map[string]interface{})["Friend"].(map[string]interface{})["id"], "failed to link resource past MaxDepth by reference")
+}
+
+func TestEnterDocument(t *testing.T) {
+	var (
+		serializer = serializers.Base{}
+		value      = reflect.ValueOf(Friend{ID: 1})
+	)
+
+	assert.Nil(t, serializer.EnterDocument(value), "received unexpected error from EnterDocument")
+	assert.True(t, serializer.IsVisiting(value), "failed to add resource to path")
+	assert.IsType(t, serializers.CycleError{}, serializer.EnterDocument(value), "failed to reject resource already along the path")
+
+	serializer.MaxDepth = 1
+
+	assert.Equal(t, serializers.DepthError{[][2]string{{"Friend", "1"}, {"Friend", "2"}}, 1}, serializer.EnterDocument(reflect.ValueOf(Friend{ID: 2})), "failed to reject resource past MaxDepth")
+
+	serializer.LeaveDocument()
+
+	assert.False(t, serializer.IsVisiting(value), "failed to remove resource from path")
+}
+
+func TestAcceptInclude(t *testing.T) {
+	var (
+		jon        = &Friend{ID: 1, Name: "Jon"}
+		jane       = &Friend{ID: 2, Name: "Jane", Friend: jon}
+		serializer = serializers.Base{}
+	)
+
+	jon.Friend = jane
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+	serializer.ReservedStrings.ID = "id"
+
+	var result, err = serializer.Accept(jon, serializers.Include(""))
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.NotContains(t, result, "linked", "sideloaded relationship not included")
+	assert.Equal(t, 2, result["Friend"].(map[string]interface{})["links"].(map[string]interface{})["Friend"].(map[string]interface{})["id"], "failed to link relationship not included by reference")
+
+	result, err = serializer.Accept(jon, serializers.Include("Friend"))
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Len(t, result["linked"].(map[string]interface{})["Friend"], 1, "failed to sideload included relationship")
+	assert.Nil(t, serializer.IncludePaths, "modified State of serializer during Accept")
+}
+
+type TContextKey struct{}
+
+type TContextHrefFormatter func(ctx context.Context, h, o string, id interface{}, c string, i []interface{}) string
+
+func (f TContextHrefFormatter) FormatHref(h, o, c string, i []interface{}) string {
+	return f(context.Background(), h, o, nil, c, i)
+}
+
+func (f TContextHrefFormatter) FormatHrefContext(ctx context.Context, h, o string, id interface{}, c string, i []interface{}) string {
+	return f(ctx, h, o, id, c, i)
+}
+
+type Follower struct {
+	ID     int
+	Friend Friend `tranq_link:"true" tranq_href:"/friends"`
+}
+
+func TestAcceptContext(t *testing.T) {
+	var (
+		ctx        = context.WithValue(context.Background(), TContextKey{}, "key")
+		cancel     = context.CancelFunc(func() {})
+		calls      = 0
+		followers  = make([]Follower, 0, 0)
+		serializer = serializers.Base{
+			HrefFormatter: TContextHrefFormatter(func(c context.Context, h, o string, id interface{}, child string, i []interface{}) string {
+				calls++
+				cancel()
+				return fmt.Sprintf("%s/%v", h, c.Value(TContextKey{}))
+			}),
+		}
+	)
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Href = "href"
+
+	for i := 0; i < 16; i++ {
+		followers = append(followers, Follower{i, Friend{ID: i}})
+	}
+
+	var result, err = serializer.AcceptContext(ctx, followers[0])
+
+	assert.Nil(t, err, "received unexpected error from AcceptContext")
+	assert.Equal(t, "/friends/key", result["Follower"].(map[string]interface{})["links"].(map[string]interface{})["Friend"].(map[string]interface{})["href"], "failed to pass context to ContextHrefFormatter")
+
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	calls = 0
+	_, err = serializer.AcceptContext(ctx, followers)
+
+	assert.Equal(t, context.Canceled, err, "failed to return error of done context")
+	assert.Equal(t, 1, calls, "failed to stop serializing resources once context was done")
+
+	_, err = serializer.AcceptContext(ctx, 1)
+	assert.Equal(t, context.Canceled, err, "failed to return error of context done before serialization")
+}
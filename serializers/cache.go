@@ -0,0 +1,274 @@
+package serializers
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// FieldMetadata contains the reflection metadata
+// of a single struct field.
+type FieldMetadata struct {
+	// Field is the struct field described.
+	Field reflect.StructField
+	// Tag is the parsed Tag of the field.
+	Tag Tag
+	// Name is the attribute name of the field.
+	Name string
+	// Link determines whether the field is marked
+	// with the TranqLink struct tag.
+	Link bool
+	// Href is the unformatted value of the
+	// field's TranqHref struct tag.
+	Href string
+}
+
+// TypeMetadata contains the reflection metadata of a type,
+// as read with the formatters, identifier strategy and tag
+// settings of a serializer.
+type TypeMetadata struct {
+	// Name is the formatted type name.
+	Name string
+	// IdentifierField is the struct field containing
+	// the identifier, if Identified is true.
+	IdentifierField reflect.StructField
+	Identified      bool
+	// IdentifierName is the attribute name of the
+	// identifier field, or the formatted ID if the
+	// type has none.
+	IdentifierName string
+	// Fields contains the attribute and link fields in
+	// declaration order, excluding ignored fields, meta
+	// fields and the identifier field.
+	Fields []FieldMetadata
+	// Meta contains the fields marked with the TranqMeta
+	// struct tag, excluding ignored fields.
+	Meta []FieldMetadata
+	// Names contains the attribute names of Fields.
+	Names map[string]struct{}
+	// SelfTemplate is the URL template of the
+	// type's self link, if HasSelf is true.
+	SelfTemplate string
+	HasSelf      bool
+	// MetaProvider and MetaProviderPtr determine whether
+	// the type or a pointer to it implements the
+	// MetaProvider or ContextMetaProvider interface.
+	MetaProvider    bool
+	MetaProviderPtr bool
+	// positions maps the index of each struct field to
+	// its position in Fields, or -1 if it has none.
+	positions []int
+	// sorted contains the positions of Fields
+	// ordered by their attribute names.
+	sorted []int
+	// streamable reports whether the attribute names of
+	// Fields are unique and those of link fields are in
+	// declaration order, allowing resources of the type
+	// to be written member by member.
+	streamable bool
+	// identifier returns the identifier
+	// of a value of the type.
+	identifier func(v reflect.Value) (interface{}, bool)
+}
+
+// Field returns the metadata of the struct field with the
+// index `i`, reporting false if it is not in Fields.
+func (t *TypeMetadata) Field(i int) (FieldMetadata, bool) {
+	if 0 > i || len(t.positions) <= i || 0 > t.positions[i] {
+		return FieldMetadata{}, false
+	}
+
+	return t.Fields[t.positions[i]], true
+}
+
+// TypeCache is a concurrency safe cache of TypeMetadata keyed
+// by reflect.Type. Serializers sharing a TypeCache must share
+// their formatters, identifier strategy, tag settings and
+// self link templates.
+type TypeCache struct {
+	types sync.Map
+}
+
+// Load returns the TypeMetadata stored for
+// the type `t`, reporting false if none is.
+func (c *TypeCache) Load(t reflect.Type) (*TypeMetadata, bool) {
+	var m, ok = c.types.Load(t)
+
+	if !ok {
+		return nil, false
+	}
+
+	return m.(*TypeMetadata), true
+}
+
+// Store stores the TypeMetadata `m` for the type `t` unless
+// metadata is already stored for it, returning the metadata
+// stored.
+func (c *TypeCache) Store(t reflect.Type, m *TypeMetadata) *TypeMetadata {
+	var actual, _ = c.types.LoadOrStore(t, m)
+
+	return actual.(*TypeMetadata)
+}
+
+// Metadata returns the TypeMetadata of the type `t`, read from
+// Base's TypeCache, or the cache of the current call to Accept
+// if it has none.
+func (b *Base) Metadata(t reflect.Type) *TypeMetadata {
+	var cache = b.TypeCache
+
+	if nil == cache {
+		cache = b.types
+	}
+
+	if nil == cache {
+		return b.NewMetadata(t)
+	} else if m, ok := cache.Load(t); ok {
+		return m
+	}
+
+	return cache.Store(t, b.NewMetadata(t))
+}
+
+// NewMetadata reads the TypeMetadata of
+// the type `t`, bypassing any TypeCache.
+func (b *Base) NewMetadata(t reflect.Type) *TypeMetadata {
+	var (
+		name, _  = TypeName(t)
+		strategy = b.identifierStrategy()
+		metadata = &TypeMetadata{
+			Name:       b.FormatTypeName(name),
+			Names:      make(map[string]struct{}),
+			identifier: strategy.Identifier,
+		}
+	)
+
+	if t.Kind() != reflect.Struct {
+		return metadata
+	}
+
+	metadata.IdentifierField, metadata.Identified = strategy.IdentifierField(t)
+	metadata.IdentifierName = b.FormatAttributeName(ID)
+
+	if metadata.Identified {
+		metadata.IdentifierName = b.AttributeName(metadata.IdentifierField, b.Tag(metadata.IdentifierField))
+	}
+
+	metadata.SelfTemplate, metadata.HasSelf, _ = b.SelfTemplate(t)
+	metadata.MetaProvider = t.Implements(metaProvider) || t.Implements(contextMetaProvider)
+	metadata.MetaProviderPtr = reflect.PtrTo(t).Implements(metaProvider) || reflect.PtrTo(t).Implements(contextMetaProvider)
+	metadata.positions = make([]int, t.NumField())
+
+	switch strategy.(type) {
+	case FieldIdentifier, TagIdentifier:
+		metadata.identifier = fieldIdentifierFunc(metadata.IdentifierField, metadata.Identified)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		var (
+			field = t.Field(i)
+			fm    = b.newFieldMetadata(field)
+		)
+
+		metadata.positions[i] = -1
+
+		if fm.Tag.Ignore {
+			continue
+		} else if IsMeta(field) {
+			metadata.Meta = append(metadata.Meta, fm)
+		} else if !(metadata.Identified && IsField(metadata.IdentifierField, i)) {
+			metadata.positions[i] = len(metadata.Fields)
+			metadata.Fields = append(metadata.Fields, fm)
+			metadata.Names[fm.Name] = struct{}{}
+		}
+	}
+
+	metadata.sorted, metadata.streamable = sortFields(metadata.Fields)
+
+	return metadata
+}
+
+// StructFieldMetadata returns the FieldMetadata of the struct
+// field `f` of the type `t`, read from the TypeMetadata of `t`
+// for attribute and link fields.
+func (b *Base) StructFieldMetadata(t reflect.Type, f reflect.StructField) FieldMetadata {
+	if 1 == len(f.Index) {
+		if field, ok := b.Metadata(t).Field(f.Index[0]); ok {
+			return field
+		}
+	}
+
+	return b.newFieldMetadata(f)
+}
+
+// newFieldMetadata reads the FieldMetadata
+// of the struct field `f`.
+func (b *Base) newFieldMetadata(f reflect.StructField) FieldMetadata {
+	var tag = b.Tag(f)
+
+	return FieldMetadata{
+		Field: f,
+		Tag:   tag,
+		Name:  b.AttributeName(f, tag),
+		Link:  "true" == f.Tag.Get(TranqLink),
+		Href:  f.Tag.Get(TranqHref),
+	}
+}
+
+// sortFields returns the positions of the FieldMetadata `f`
+// ordered by attribute name, reporting whether the names are
+// unique and the link fields already ordered by them.
+func sortFields(f []FieldMetadata) ([]int, bool) {
+	var (
+		positions = make([]int, len(f))
+		ordered   = true
+		last      = -1
+	)
+
+	for i := 0; i < len(positions); i++ {
+		positions[i] = i
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		return f[positions[i]].Name < f[positions[j]].Name
+	})
+
+	for i := 0; i < len(positions); i++ {
+		if 0 < i && f[positions[i-1]].Name == f[positions[i]].Name {
+			ordered = false
+		} else if f[positions[i]].Link {
+			ordered = ordered && last < positions[i]
+			last = positions[i]
+		}
+	}
+
+	return positions, ordered
+}
+
+// identifierStrategy returns Base's IdentifierStrategy,
+// or DefaultIdentifierStrategy if it has none.
+func (b *Base) identifierStrategy() IdentifierStrategy {
+	if nil == b.IdentifierStrategy {
+		return DefaultIdentifierStrategy
+	}
+
+	return b.IdentifierStrategy
+}
+
+// fieldIdentifierFunc returns a function reading identifiers
+// from the struct field `f`, or reporting no identifier if `ok`
+// is false.
+func fieldIdentifierFunc(f reflect.StructField, ok bool) func(v reflect.Value) (interface{}, bool) {
+	return func(v reflect.Value) (interface{}, bool) {
+		if !ok {
+			return nil, false
+		}
+
+		var value, err = v.FieldByIndexErr(f.Index)
+
+		if nil != err || !value.CanInterface() {
+			return nil, false
+		}
+
+		return value.Interface(), true
+	}
+}
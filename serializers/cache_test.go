@@ -0,0 +1,143 @@
+package serializers_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Cached struct {
+	ID       int
+	Title    string `tranq:"headline"`
+	Secret   string `tranq:"-"`
+	Author   Friend `tranq_link:"true" tranq_href:"/friends"`
+	Revision int    `tranq_meta:"true"`
+}
+
+func TestTypeCache(t *testing.T) {
+	var (
+		cache = new(serializers.TypeCache)
+		typ   = reflect.TypeOf(Cached{})
+		first = &serializers.TypeMetadata{Name: "first"}
+	)
+
+	_, ok := cache.Load(typ)
+	assert.False(t, ok, "reported metadata for type not stored")
+
+	assert.Equal(t, first, cache.Store(typ, first), "failed to store metadata")
+	assert.Equal(t, first, cache.Store(typ, &serializers.TypeMetadata{Name: "second"}), "failed to keep metadata already stored")
+
+	var loaded, found = cache.Load(typ)
+
+	assert.True(t, found, "failed to load stored metadata")
+	assert.Equal(t, first, loaded, "failed to load stored metadata")
+}
+
+func TestMetadata(t *testing.T) {
+	var (
+		serializer = &serializers.Base{
+			TypeNameFormatter:      serializers.NamingFormatterFunc(strings.ToLower),
+			AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+		}
+		metadata = serializer.Metadata(reflect.TypeOf(Cached{}))
+		names    = make([]string, 0, 0)
+	)
+
+	for _, field := range metadata.Fields {
+		names = append(names, field.Name)
+	}
+
+	assert.Equal(t, "cached", metadata.Name, "failed to format type name")
+	assert.True(t, metadata.Identified, "failed to locate identifier field")
+	assert.Equal(t, "ID", metadata.IdentifierField.Name, "failed to locate identifier field")
+	assert.Equal(t, []string{"headline", "author"}, names, "failed to collect attribute and link fields")
+	assert.Equal(t, map[string]struct{}{"headline": struct{}{}, "author": struct{}{}}, metadata.Names, "failed to collect attribute names")
+	assert.True(t, metadata.Fields[1].Link, "failed to read link tag")
+	assert.Equal(t, "/friends", metadata.Fields[1].Href, "failed to read href tag")
+	assert.Equal(t, "Revision", metadata.Meta[0].Field.Name, "failed to collect meta fields")
+
+	var field, ok = metadata.Field(3)
+
+	assert.True(t, ok, "failed to look up field by index")
+	assert.Equal(t, "author", field.Name, "failed to look up field by index")
+
+	_, ok = metadata.Field(2)
+	assert.False(t, ok, "reported ignored field")
+}
+
+func TestMetadataTypeCache(t *testing.T) {
+	var (
+		calls     = 0
+		cache     = new(serializers.TypeCache)
+		formatter = serializers.NamingFormatterFunc(func(s string) string {
+			calls++
+			return strings.ToLower(s)
+		})
+		first  = &serializers.Base{AttributeNameFormatter: formatter, TypeCache: cache}
+		second = &serializers.Base{AttributeNameFormatter: formatter, TypeCache: cache}
+	)
+
+	var _, err = first.Accept(Cached{ID: 1, Title: "Lorem"})
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var formatted = calls
+
+	_, err = second.Accept(Cached{ID: 2, Title: "Ipsum"})
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	assert.Equal(t, formatted, calls, "failed to reuse cached metadata across serializers")
+}
+
+func BenchmarkAcceptTypeCache(b *testing.B) {
+	var serializer = NewV1()
+
+	serializer.TypeCache = new(serializers.TypeCache)
+	benchmarkAccept(b, serializer)
+}
+
+func BenchmarkAcceptWithoutTypeCache(b *testing.B) {
+	benchmarkAccept(b, NewV1())
+}
+
+func BenchmarkMetadata(b *testing.B) {
+	var (
+		serializer = NewV1()
+		typ        = reflect.TypeOf(Cached{})
+	)
+
+	serializer.TypeCache = new(serializers.TypeCache)
+
+	for i := 0; i < b.N; i++ {
+		serializer.Metadata(typ)
+	}
+}
+
+func BenchmarkNewMetadata(b *testing.B) {
+	var (
+		serializer = NewV1()
+		typ        = reflect.TypeOf(Cached{})
+	)
+
+	for i := 0; i < b.N; i++ {
+		serializer.NewMetadata(typ)
+	}
+}
+
+// benchmarkAccept serializes a single resource per iteration,
+// where reading type metadata dominates without a TypeCache.
+func benchmarkAccept(b *testing.B, s *serializers.V1) {
+	var value = Cached{ID: 1, Title: "Lorem", Author: Friend{ID: 2}, Revision: 3}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Accept(value); nil != err {
+			b.Fatal(err)
+		}
+	}
+}
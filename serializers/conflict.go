@@ -0,0 +1,58 @@
+package serializers
+
+import (
+	"fmt"
+)
+
+// ConflictPolicy determines how a serializer resolves
+// a linked resource appearing more than once within a
+// document, identified by its formatted type name and
+// identifier, with differing values.
+type ConflictPolicy int
+
+const (
+	// ConflictFirstWins keeps the first value serialized
+	// for a resource, ignoring all later values.
+	ConflictFirstWins ConflictPolicy = iota
+	// ConflictLastWins replaces the serialized resource
+	// with the last value encountered.
+	ConflictLastWins
+	// ConflictMerge merges later values into the serialized
+	// resource, with later values replacing earlier ones
+	// attribute by attribute.
+	ConflictMerge
+	// ConflictFail fails serialization with a
+	// DocumentConflictError.
+	ConflictFail
+)
+
+// DocumentConflictError occurs when a linked resource
+// appears more than once within a document with differing
+// values and the serializer's ConflictPolicy is ConflictFail.
+type DocumentConflictError struct {
+	Key [2]string
+}
+
+// Error implements the `error` interface for the
+// DocumentConflictError type.
+func (d DocumentConflictError) Error() string {
+	return fmt.Sprintf("linked resource `%s:%s` appears more than once with differing values", d.Key[0], d.Key[1])
+}
+
+// mergeDocument copies the members of the serialized
+// document `s` into `d`, merging members that are
+// themselves objects.
+func mergeDocument(d, s map[string]interface{}) {
+	for key, value := range s {
+		var (
+			dst, isDst = d[key].(map[string]interface{})
+			src, isSrc = value.(map[string]interface{})
+		)
+
+		if isDst && isSrc {
+			mergeDocument(dst, src)
+		} else {
+			d[key] = value
+		}
+	}
+}
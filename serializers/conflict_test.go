@@ -0,0 +1,122 @@
+package serializers_test
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Member struct {
+	ID    int
+	Name  string
+	Email string
+	Roles map[string]bool
+}
+
+type Team struct {
+	ID      int
+	Lead    Member   `tranq_link:"true"`
+	Members []Member `tranq_link:"true"`
+}
+
+func linkedMembers(t *testing.T, p serializers.ConflictPolicy) ([]interface{}, error) {
+	var serializer = serializers.Base{ConflictPolicy: p}
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+
+	var result, err = serializer.Accept(Team{1, Member{1, "Jon", "", map[string]bool{"lead": true}}, []Member{
+		Member{1, "Jon", "jon@example.com", nil},
+		Member{2, "Jane", "", nil},
+	}})
+
+	if nil != err {
+		return nil, err
+	}
+
+	return result["linked"].(map[string]interface{})["Member"].([]interface{}), nil
+}
+
+func TestDocumentConflictError(t *testing.T) {
+	var err = serializers.DocumentConflictError{[2]string{"persons", "1"}}
+
+	assert.Equal(t, "linked resource `persons:1` appears more than once with differing values", err.Error(), "failed to return correct error message for DocumentConflictError")
+}
+
+func TestConflictFirstWins(t *testing.T) {
+	var linked, err = linkedMembers(t, serializers.ConflictFirstWins)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Len(t, linked, 2, "failed to deduplicate linked documents by type and identifier")
+	assert.Equal(t, "", linked[0].(map[string]interface{})["Email"], "failed to keep first value")
+	assert.Equal(t, map[string]interface{}{"lead": true}, linked[0].(map[string]interface{})["Roles"], "failed to keep first value")
+}
+
+func TestConflictLastWins(t *testing.T) {
+	var linked, err = linkedMembers(t, serializers.ConflictLastWins)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Len(t, linked, 2, "failed to deduplicate linked documents by type and identifier")
+	assert.Equal(t, "jon@example.com", linked[0].(map[string]interface{})["Email"], "failed to keep last value")
+	assert.Nil(t, linked[0].(map[string]interface{})["Roles"], "failed to keep last value")
+}
+
+func TestConflictMerge(t *testing.T) {
+	var linked, err = linkedMembers(t, serializers.ConflictMerge)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Len(t, linked, 2, "failed to deduplicate linked documents by type and identifier")
+	assert.Equal(t, "jon@example.com", linked[0].(map[string]interface{})["Email"], "failed to merge later values")
+	assert.Equal(t, "Jon", linked[0].(map[string]interface{})["Name"], "failed to merge later values")
+}
+
+func TestConflictFail(t *testing.T) {
+	var _, err = linkedMembers(t, serializers.ConflictFail)
+
+	assert.Equal(t, serializers.DocumentConflictError{[2]string{"Member", "1"}}, err, "failed to return serializers.DocumentConflictError")
+}
+
+func TestConflictFailIdentical(t *testing.T) {
+	var serializer = serializers.Base{ConflictPolicy: serializers.ConflictFail}
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+
+	var jon = Member{1, "Jon", "", nil}
+	var _, err = serializer.Accept(Team{1, jon, []Member{jon}})
+
+	assert.Nil(t, err, "failed to accept identical values of the same linked resource")
+}
+
+func TestConflictPrimaryResources(t *testing.T) {
+	var (
+		jon  = &Friend{ID: 1, Name: "Jon"}
+		jane = &Friend{ID: 2, Name: "Jane", Friend: jon}
+		joe  = &Friend{ID: 3, Name: "Joe"}
+	)
+
+	jon.Friend = jane
+	jane.Friend = joe
+
+	for _, policy := range []serializers.ConflictPolicy{serializers.ConflictFirstWins, serializers.ConflictLastWins, serializers.ConflictMerge, serializers.ConflictFail} {
+		var serializer = serializers.Base{ConflictPolicy: policy}
+
+		serializer.ReservedStrings.ID = "id"
+		serializer.ReservedStrings.Linked = "linked"
+
+		var result, err = serializer.Accept([]*Friend{jon, jane})
+
+		if !assert.Nil(t, err, "received unexpected error from Accept") {
+			continue
+		}
+
+		var linked = result["linked"].(map[string]interface{})["Friend"].([]interface{})
+
+		assert.Len(t, result["Friend"], 2, "failed to serialize primary resources")
+		assert.Len(t, linked, 1, "linked primary resources")
+		assert.Equal(t, 3, linked[0].(map[string]interface{})["id"], "failed to link resource outside of primary data")
+	}
+}
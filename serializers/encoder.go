@@ -0,0 +1,417 @@
+package serializers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// StreamingSerializer provides an interface for Serializers
+// writing documents to an Encoder while serializing them,
+// rather than building the whole document first.
+type StreamingSerializer interface {
+	Serializer
+	// Stream serializes `i` as Accept would, writing
+	// the document to the Encoder `e`.
+	Stream(e *Encoder, i interface{}, options ...Option) error
+	// StreamContext serializes `i` as AcceptContext
+	// would, writing the document to the Encoder `e`.
+	StreamContext(ctx context.Context, e *Encoder, i interface{}, options ...Option) error
+}
+
+// Encoder writes the documents produced by a Serializer to an
+// io.Writer as JSON, byte-identical to the output of passing
+// the result of the Serializer's Accept method to json.Marshal.
+// Serializers implementing the StreamingSerializer interface
+// write their documents as they serialize them, all others
+// are encoded once Accept returns.
+type Encoder struct {
+	Serializer Serializer
+	writer     io.Writer
+	buffer     *bufio.Writer
+	scratch    []byte
+}
+
+// NewEncoder returns an Encoder writing
+// the documents of `s` to `w`.
+func NewEncoder(w io.Writer, s Serializer) *Encoder {
+	return &Encoder{
+		Serializer: s,
+		writer:     w,
+		buffer:     bufio.NewWriter(w),
+	}
+}
+
+// Encode serializes `i` with the Encoder's Serializer, writing
+// the document. If an error occurs after part of the document
+// has been written to the underlying io.Writer, that part is
+// not retracted.
+func (e *Encoder) Encode(i interface{}, options ...Option) error {
+	return e.EncodeContext(context.Background(), i, options...)
+}
+
+// EncodeContext serializes `i` as Encode does, passing `ctx`
+// to the AcceptContext or StreamContext method of the Encoder's
+// Serializer. Once `ctx` is done, serialization stops with its
+// error and the remainder of the document is not written.
+func (e *Encoder) EncodeContext(ctx context.Context, i interface{}, options ...Option) error {
+	var err error
+
+	if streaming, ok := e.Serializer.(StreamingSerializer); ok {
+		err = streaming.StreamContext(ctx, e, i, options...)
+	} else {
+		var mapping map[string]interface{}
+
+		if mapping, err = e.Serializer.AcceptContext(ctx, i, options...); nil == err {
+			err = e.WriteValue(mapping)
+		}
+	}
+
+	if nil != err {
+		e.buffer.Reset(e.writer)
+		return err
+	}
+
+	return e.buffer.Flush()
+}
+
+// WriteRaw writes the string `s` to the document unchanged,
+// i.e. the delimiters of objects and arrays.
+func (e *Encoder) WriteRaw(s string) error {
+	var _, err = e.buffer.WriteString(s)
+
+	return err
+}
+
+// WriteKey writes the object member name
+// `k` to the document, followed by a colon.
+func (e *Encoder) WriteKey(k string) error {
+	if err := e.writeString(k); nil != err {
+		return err
+	}
+
+	return e.WriteRaw(":")
+}
+
+// WriteValue writes the value `x` to the document as json.Marshal
+// would encode it. Objects of the type map[string]interface{}
+// and arrays of the type []interface{} are written member by
+// member, as are strings, numbers and booleans; all other
+// values are passed to json.Marshal.
+func (e *Encoder) WriteValue(x interface{}) error {
+	var err error
+
+	switch value := x.(type) {
+	case map[string]interface{}:
+		if nil == value {
+			return e.WriteRaw("null")
+		}
+
+		var keys = make([]string, 0, len(value))
+
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		if err = e.WriteRaw("{"); nil != err {
+			return err
+		}
+
+		for j := 0; j < len(keys); j++ {
+			if 0 < j {
+				if err = e.WriteRaw(","); nil != err {
+					return err
+				}
+			}
+
+			if err = e.WriteKey(keys[j]); nil != err {
+				return err
+			} else if err = e.WriteValue(value[keys[j]]); nil != err {
+				return err
+			}
+		}
+
+		return e.WriteRaw("}")
+	case []interface{}:
+		if nil == value {
+			return e.WriteRaw("null")
+		} else if err = e.WriteRaw("["); nil != err {
+			return err
+		}
+
+		for j := 0; j < len(value); j++ {
+			if 0 < j {
+				if err = e.WriteRaw(","); nil != err {
+					return err
+				}
+			}
+
+			if err = e.WriteValue(value[j]); nil != err {
+				return err
+			}
+		}
+
+		return e.WriteRaw("]")
+	case nil:
+		return e.WriteRaw("null")
+	case bool:
+		return e.WriteRaw(strconv.FormatBool(value))
+	case string:
+		return e.writeString(value)
+	case int:
+		return e.writeBytes(strconv.AppendInt(e.scratch[:0], int64(value), 10))
+	case int8:
+		return e.writeBytes(strconv.AppendInt(e.scratch[:0], int64(value), 10))
+	case int16:
+		return e.writeBytes(strconv.AppendInt(e.scratch[:0], int64(value), 10))
+	case int32:
+		return e.writeBytes(strconv.AppendInt(e.scratch[:0], int64(value), 10))
+	case int64:
+		return e.writeBytes(strconv.AppendInt(e.scratch[:0], value, 10))
+	case uint:
+		return e.writeBytes(strconv.AppendUint(e.scratch[:0], uint64(value), 10))
+	case uint8:
+		return e.writeBytes(strconv.AppendUint(e.scratch[:0], uint64(value), 10))
+	case uint16:
+		return e.writeBytes(strconv.AppendUint(e.scratch[:0], uint64(value), 10))
+	case uint32:
+		return e.writeBytes(strconv.AppendUint(e.scratch[:0], uint64(value), 10))
+	case uint64:
+		return e.writeBytes(strconv.AppendUint(e.scratch[:0], value, 10))
+	case float32:
+		if !math.IsInf(float64(value), 0) && !math.IsNaN(float64(value)) {
+			return e.writeBytes(appendFloat(e.scratch[:0], float64(value), 32))
+		}
+	case float64:
+		if !math.IsInf(value, 0) && !math.IsNaN(value) {
+			return e.writeBytes(appendFloat(e.scratch[:0], value, 64))
+		}
+	}
+
+	var encoded []byte
+
+	if encoded, err = json.Marshal(x); nil != err {
+		return err
+	}
+
+	_, err = e.buffer.Write(encoded)
+
+	return err
+}
+
+// writeMember writes the object member name `k`, preceded
+// by a comma unless it is the first of the `n` written.
+func (e *Encoder) writeMember(n *int, k string) error {
+	if 0 < *n {
+		if err := e.WriteRaw(","); nil != err {
+			return err
+		}
+	}
+
+	*n++
+
+	return e.WriteKey(k)
+}
+
+// writeLeaf writes the boolean, number or string value `v`
+// to the document as WriteValue writes it once interfaced,
+// reporting false if `v` is of any other reflect.Kind or a
+// number json.Marshal rejects, leaving it unwritten.
+func (e *Encoder) writeLeaf(v reflect.Value) (bool, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		return true, e.WriteRaw(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true, e.writeBytes(strconv.AppendInt(e.scratch[:0], v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true, e.writeBytes(strconv.AppendUint(e.scratch[:0], v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return true, e.writeBytes(appendFloat(e.scratch[:0], f, v.Type().Bits()))
+		}
+	case reflect.String:
+		return true, e.writeString(v.String())
+	}
+
+	return false, nil
+}
+
+// writeIdentifier writes the identifier `id` to the document
+// as a string, formatted as fmt.Sprint formats it.
+func (e *Encoder) writeIdentifier(id interface{}) error {
+	var b = append(e.scratch[:0], '"')
+
+	switch value := id.(type) {
+	case string:
+		return e.writeString(value)
+	case int:
+		b = strconv.AppendInt(b, int64(value), 10)
+	case int32:
+		b = strconv.AppendInt(b, int64(value), 10)
+	case int64:
+		b = strconv.AppendInt(b, value, 10)
+	case uint:
+		b = strconv.AppendUint(b, uint64(value), 10)
+	case uint32:
+		b = strconv.AppendUint(b, uint64(value), 10)
+	case uint64:
+		b = strconv.AppendUint(b, value, 10)
+	default:
+		return e.writeString(fmt.Sprint(id))
+	}
+
+	return e.writeBytes(append(b, '"'))
+}
+
+// writeBytes writes the bytes `b` to the document,
+// keeping them as scratch space for later values.
+func (e *Encoder) writeBytes(b []byte) error {
+	var _, err = e.buffer.Write(b)
+
+	e.scratch = b
+
+	return err
+}
+
+// writeString writes the string `s` to the document as
+// json.Marshal would encode it. Strings json.Marshal escapes
+// differently between releases of Go, those containing
+// control characters other than newlines, carriage returns
+// and tabs or invalid UTF-8, are passed to json.Marshal.
+func (e *Encoder) writeString(s string) error {
+	for i := 0; i < len(s); {
+		var c = s[i]
+
+		if c < utf8.RuneSelf {
+			if c < 0x20 && c != '\n' && c != '\r' && c != '\t' {
+				return e.marshalString(s)
+			}
+
+			i++
+			continue
+		}
+
+		var r, size = utf8.DecodeRuneInString(s[i:])
+
+		if r == utf8.RuneError && size == 1 {
+			return e.marshalString(s)
+		}
+
+		i += size
+	}
+
+	var (
+		start = 0
+		err   error
+	)
+
+	if err = e.buffer.WriteByte('"'); nil != err {
+		return err
+	}
+
+	for i := 0; i < len(s); {
+		var (
+			c       = s[i]
+			escaped string
+			size    = 1
+		)
+
+		switch c {
+		case '"':
+			escaped = `\"`
+		case '\\':
+			escaped = `\\`
+		case '\n':
+			escaped = `\n`
+		case '\r':
+			escaped = `\r`
+		case '\t':
+			escaped = `\t`
+		case '<':
+			escaped = `\u003c`
+		case '>':
+			escaped = `\u003e`
+		case '&':
+			escaped = `\u0026`
+		default:
+			if c >= utf8.RuneSelf {
+				var r rune
+
+				r, size = utf8.DecodeRuneInString(s[i:])
+
+				if r == '\u2028' {
+					escaped = `\u2028`
+				} else if r == '\u2029' {
+					escaped = `\u2029`
+				}
+			}
+		}
+
+		if 0 < len(escaped) {
+			if _, err = e.buffer.WriteString(s[start:i]); nil != err {
+				return err
+			} else if _, err = e.buffer.WriteString(escaped); nil != err {
+				return err
+			}
+
+			start = i + size
+		}
+
+		i += size
+	}
+
+	if _, err = e.buffer.WriteString(s[start:]); nil != err {
+		return err
+	}
+
+	return e.buffer.WriteByte('"')
+}
+
+// marshalString writes the string `s`
+// to the document using json.Marshal.
+func (e *Encoder) marshalString(s string) error {
+	var encoded, err = json.Marshal(s)
+
+	if nil == err {
+		_, err = e.buffer.Write(encoded)
+	}
+
+	return err
+}
+
+// appendFloat appends the finite floating point number `f` of
+// `bits` bits to `b`, formatted as json.Marshal formats it.
+func appendFloat(b []byte, f float64, bits int) []byte {
+	var (
+		abs    = math.Abs(f)
+		format = byte('f')
+	)
+
+	if 0 != abs {
+		if 64 == bits && (abs < 1e-6 || abs >= 1e21) || 32 == bits && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+
+	if 'e' == format {
+		var n = len(b)
+
+		if 4 <= n && 'e' == b[n-4] && '-' == b[n-3] && '0' == b[n-2] {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+
+	return b
+}
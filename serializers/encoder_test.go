@@ -0,0 +1,372 @@
+package serializers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Entry struct {
+	ID       int
+	Title    string
+	Created  time.Time
+	Ratio    float64
+	Tags     map[string]bool
+	Author   Friend   `tranq_link:"true"`
+	Readers  []Friend `tranq_link:"true"`
+	Revision int      `tranq_meta:"true"`
+}
+
+type Journal struct {
+	ID      uint64    `tranq_self:"/journals"`
+	Authors []*Friend `tranq_link:"true"`
+	Editor  *Friend   `tranq_link:"true" tranq_href:"/editors"`
+	Pages   *int
+	Rating  float32
+	Public  bool
+	Notes   interface{} `tranq:",omitempty"`
+	Summary string      `tranq:",omitempty"`
+}
+
+type Reversed struct {
+	ID    int
+	Zed   Friend `tranq_link:"true"`
+	Alpha Friend `tranq_link:"true"`
+}
+
+type Renamed struct {
+	ID    int
+	Title string `tranq:"name"`
+	Name  string
+}
+
+type static map[string]interface{}
+
+func (s static) Accept(i interface{}, options ...serializers.Option) (map[string]interface{}, error) {
+	return s, nil
+}
+
+func (s static) AcceptContext(ctx context.Context, i interface{}, options ...serializers.Option) (map[string]interface{}, error) {
+	return s, ctx.Err()
+}
+
+type failingWriter struct{}
+
+func (f failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func entries() []Entry {
+	var created = time.Date(2014, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	return []Entry{
+		{1, "<b>Tom & Jerry</b>", created, 0.1, map[string]bool{"z": true, "a": false}, Friend{ID: 2, Name: "Jon"}, []Friend{{ID: 3, Name: "Jane"}}, 1},
+		{2, "Ipsum  ", created, 1e21, nil, Friend{ID: 3, Name: "Jane"}, nil, 2},
+	}
+}
+
+func collection(n int) []Entry {
+	var entries = make([]Entry, 0, n)
+
+	for i := 0; i < n; i++ {
+		entries = append(entries, Entry{ID: i, Title: "Lorem", Author: Friend{ID: i % 10, Name: "Jon"}})
+	}
+
+	return entries
+}
+
+func assertEncodes(t *testing.T, s serializers.Serializer, i interface{}, options ...serializers.Option) {
+	var (
+		buffer      bytes.Buffer
+		result, err = s.Accept(i, options...)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var expected, _ = json.Marshal(result)
+
+	assert.Nil(t, serializers.NewEncoder(&buffer, s).Encode(i, options...), "received unexpected error from Encode")
+	assert.Equal(t, string(expected), buffer.String(), "failed to encode output identical to json.Marshal")
+}
+
+func TestEncoderV1(t *testing.T) {
+	var (
+		u, _    = url.Parse("/entries")
+		options = []serializers.Option{
+			serializers.Paginate(serializers.OffsetPaginator{}, u, serializers.PageResult{Limit: 2, Total: 4, HasTotal: true}),
+			serializers.Meta(map[string]interface{}{"request": "<abc>"}),
+		}
+	)
+
+	assertEncodes(t, NewV1(), entries())
+	assertEncodes(t, NewV1(), entries(), options...)
+	assertEncodes(t, NewV1(), entries()[0])
+	assertEncodes(t, NewV1(), []*Entry{nil})
+	assertEncodes(t, NewV1(), []Entry{})
+	assertEncodes(t, NewV1(), (*Entry)(nil))
+	assertEncodes(t, NewV1(), entries(), serializers.Include("author"))
+}
+
+func journals() []Journal {
+	var pages = 12
+
+	return []Journal{
+		{ID: 1, Authors: []*Friend{nil, {ID: 2, Name: "Jane"}}, Editor: &Friend{ID: 1, Name: "Jon"}, Pages: &pages, Rating: 0.1, Notes: map[string]interface{}{"draft": true}},
+		{ID: 2, Rating: 1e21, Public: true, Summary: "<Lorem>"},
+	}
+}
+
+func NewBase() *serializers.Base {
+	var base = NewV1().Base
+
+	base.ReservedStrings.IDs = "ids"
+	base.ReservedStrings.Linked = "linked"
+	base.ReservedStrings.Href = "href"
+	base.ReservedStrings.Self = "self"
+
+	return &base
+}
+
+func TestEncoderV1Resources(t *testing.T) {
+	var journals = journals()
+
+	assertEncodes(t, NewV1(), journals)
+	assertEncodes(t, NewV1(), journals[0], serializers.Include("editor", "authors"))
+	assertEncodes(t, NewV1(), journals, serializers.Fields("journals", "rating", "editor"))
+	assertEncodes(t, NewV1(), []Reversed{{1, Friend{ID: 2, Name: "Jon"}, Friend{ID: 3, Name: "Jane"}}}, serializers.Include("zed", "alpha"))
+	assertEncodes(t, NewV1(), []Renamed{{1, "Lorem", "Ipsum"}})
+	assertEncodes(t, NewV1(), []Article{{"a/1", "Lorem"}})
+}
+
+func TestEncoderV1ReservedStrings(t *testing.T) {
+	var serializer = NewV1()
+
+	serializer.ReservedStrings.Data = "primary"
+
+	assertEncodes(t, serializer, entries(), serializers.Meta(map[string]interface{}{"total": 2}))
+
+	serializer = NewV1()
+	serializer.ReservedStrings.ID = "key"
+	serializer.ReservedStrings.Type = "kind"
+	serializer.ReservedStrings.Attributes = "values"
+
+	assertEncodes(t, serializer, entries(), serializers.Include("author"))
+
+	serializer.ReservedStrings.Type = "key"
+
+	assertEncodes(t, serializer, entries())
+}
+
+func TestEncoderBase(t *testing.T) {
+	var serializer = &serializers.Base{
+		TypeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+	}
+
+	serializer.ReservedStrings.ID = "id"
+	serializer.ReservedStrings.IDs = "ids"
+	serializer.ReservedStrings.Type = "type"
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+	serializer.ReservedStrings.Meta = "meta"
+
+	assertEncodes(t, serializer, entries())
+	assertEncodes(t, serializer, entries()[0], serializers.Meta(map[string]interface{}{"total": 1}))
+	assertEncodes(t, new(serializers.Base), entries())
+	assertEncodes(t, NewBase(), entries(), serializers.Include("author"))
+	assertEncodes(t, NewBase(), []*Entry{nil, &entries()[1]})
+}
+
+func TestEncoderBaseResources(t *testing.T) {
+	var (
+		journals  = journals()
+		templates = NewBase()
+		merging   = NewBase()
+		jon       = &Friend{ID: 1, Name: "Jon"}
+		u, _      = url.Parse("/journals")
+	)
+
+	templates.LinkTemplates = true
+	merging.ConflictPolicy = serializers.ConflictMerge
+	jon.Friend = &Friend{ID: 2, Name: "Jane", Friend: jon}
+
+	assertEncodes(t, NewBase(), journals)
+	assertEncodes(t, NewBase(), journals[0], serializers.Include("editor", "authors"))
+	assertEncodes(t, NewBase(), journals, serializers.Fields("journals", "rating", "editor"))
+	assertEncodes(t, NewBase(), []Reversed{{1, Friend{ID: 2, Name: "Jon"}, Friend{ID: 3, Name: "Jane"}}}, serializers.Meta(map[string]interface{}{"total": 1}))
+	assertEncodes(t, NewBase(), []Renamed{{1, "Lorem", "Ipsum"}})
+	assertEncodes(t, NewBase(), []Article{{"a/1", "Lorem"}})
+	assertEncodes(t, NewBase(), jon)
+	assertEncodes(t, templates, journals, serializers.Paginate(serializers.OffsetPaginator{}, u, serializers.PageResult{Limit: 2, Total: 4, HasTotal: true}))
+	assertEncodes(t, merging, []Friend{{ID: 3, Friend: &Friend{ID: 1, Name: "Jon"}}, {ID: 4, Friend: &Friend{ID: 1, Friend: &Friend{ID: 2}}}})
+}
+
+func TestEncoderError(t *testing.T) {
+	var buffer bytes.Buffer
+
+	var err = serializers.NewEncoder(&buffer, NewV1()).Encode([]interface{}{1})
+
+	assert.IsType(t, serializers.UnsupportedKindError{}, err, "failed to return serializers.UnsupportedKindError")
+	assert.Equal(t, 0, buffer.Len(), "wrote buffered output of failed document")
+
+	err = serializers.NewEncoder(failingWriter{}, NewV1()).Encode(entries())
+	assert.EqualError(t, err, "write failed", "failed to return error of io.Writer")
+
+	err = serializers.NewEncoder(&buffer, NewV1()).Encode(Journal{ID: 1, Rating: float32(math.Inf(1))})
+	assert.IsType(t, &json.UnsupportedValueError{}, err, "failed to return error of json.Marshal for unsupported value")
+}
+
+func streamingSerializers() map[string]serializers.Serializer {
+	var (
+		v1   = NewV1()
+		base = NewBase()
+	)
+
+	v1.TypeCache = new(serializers.TypeCache)
+	base.TypeCache = new(serializers.TypeCache)
+
+	return map[string]serializers.Serializer{"V1": v1, "Base": base}
+}
+
+func TestEncoderAttributes(t *testing.T) {
+	type Attributes struct {
+		ID       int
+		Extra    interface{}
+		Err      error
+		Nickname *string
+		Created  *time.Time
+		Updated  time.Time
+		Settings map[string]interface{}
+		Editor   *Friend `tranq_link:"true"`
+	}
+
+	var (
+		nickname = "<nick>"
+		created  = time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+		cases    = map[string]Attributes{
+			"nil interfaces": {ID: 1},
+			"nil pointers":   {ID: 2, Extra: (*int)(nil)},
+			"marshalers":     {ID: 3, Extra: created, Created: &created, Updated: created},
+			"maps":           {ID: 4, Extra: map[string]int{"b": 2, "a": 1}, Settings: map[string]interface{}{"theme": "dark", "size": nil}},
+			"values":         {ID: 5, Extra: "extra", Nickname: &nickname, Editor: &Friend{ID: 6}},
+		}
+	)
+
+	for name, serializer := range streamingSerializers() {
+		for description, value := range cases {
+			t.Run(name+"/"+description, func(t *testing.T) {
+				assertEncodes(t, serializer, value)
+				assertEncodes(t, serializer, []Attributes{value, value})
+			})
+		}
+	}
+}
+
+func TestEncoderAllocations(t *testing.T) {
+	var entries = collection(100)
+
+	for name, serializer := range streamingSerializers() {
+		var (
+			encoding = testing.AllocsPerRun(10, func() {
+				serializers.NewEncoder(io.Discard, serializer).Encode(entries)
+			})
+			marshaling = testing.AllocsPerRun(10, func() {
+				var result, _ = serializer.Accept(entries)
+
+				json.Marshal(result)
+			})
+		)
+
+		assert.Less(t, encoding, marshaling/2, "failed to write primary resources of %s without building them first", name)
+	}
+}
+
+func TestEncoderContext(t *testing.T) {
+	for _, serializer := range []serializers.Serializer{NewV1(), new(serializers.Base)} {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			calls       = 0
+			buffer      bytes.Buffer
+			err         = serializers.NewEncoder(&buffer, serializer).EncodeContext(ctx, drafts(16, cancel, &calls))
+		)
+
+		assert.Equal(t, context.Canceled, err, "failed to return error of done context")
+		assert.Equal(t, 1, calls, "failed to stop serializing resources once context was done")
+		assert.Equal(t, 0, buffer.Len(), "wrote document after context was done")
+	}
+}
+
+func TestEncoderWriteValue(t *testing.T) {
+	var value = static{
+		"b": []interface{}{1, "<", nil, map[string]interface{}(nil)},
+		"a": map[string]string{"y": "1", "x": "2"},
+		"c": []interface{}(nil),
+		"&": json.RawMessage(`{ "raw" : true }`),
+	}
+
+	assertEncodes(t, value, nil)
+}
+
+func TestEncoderWriteValueLeaves(t *testing.T) {
+	var value = static{
+		"strings": []interface{}{
+			"", `"quoted" \\ <b>&amp;</b>`, "line\nfeed\r\ttab", "\x00\x1f\b\f\x7f",
+			"caf\u00e9 \u2028\u2029 \U0001f600", "invalid \xff\xfe utf8",
+		},
+		"numbers": []interface{}{
+			int8(-8), int16(16), int32(-32), int64(1 << 62), uint(7), uint8(8), uint16(16), uint32(32), uint64(1 << 63),
+			0.0, -0.0, 1.5, 1e-7, 1e21, 123456789.125, 1e-300, float32(3.14), float32(1e-7), float32(1e21),
+		},
+		"booleans":    []interface{}{true, false},
+		"\u2028<key>": nil,
+	}
+
+	assertEncodes(t, value, nil)
+}
+
+func BenchmarkEncode(b *testing.B) {
+	var entries = collection(1000)
+
+	for name, serializer := range streamingSerializers() {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if err := serializers.NewEncoder(io.Discard, serializer).Encode(entries); nil != err {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMarshalAccept(b *testing.B) {
+	var entries = collection(1000)
+
+	for name, serializer := range streamingSerializers() {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				var result, err = serializer.Accept(entries)
+
+				if nil != err {
+					b.Fatal(err)
+				} else if _, err = json.Marshal(result); nil != err {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
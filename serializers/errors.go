@@ -0,0 +1,356 @@
+package serializers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+const (
+	// MemberErrors is the top level member of a JSON API
+	// document containing error objects.
+	MemberErrors = "errors"
+	// MemberID is the member of an error object containing
+	// a unique identifier for the occurrence of the problem.
+	MemberID = "id"
+	// MemberStatus is the member of an error object
+	// containing the HTTP status code as a string.
+	MemberStatus = "status"
+	// MemberCode is the member of an error object containing
+	// an application specific error code.
+	MemberCode = "code"
+	// MemberTitle is the member of an error object
+	// containing a summary of the problem.
+	MemberTitle = "title"
+	// MemberDetail is the member of an error object containing
+	// an explanation of the occurrence of the problem.
+	MemberDetail = "detail"
+	// MemberSource is the member of an error object
+	// referencing the source of the problem.
+	MemberSource = "source"
+	// MemberPointer is the member of an error object's source
+	// containing a JSON Pointer to the offending value.
+	MemberPointer = "pointer"
+	// MemberParameter is the member of an error object's
+	// source naming the offending query parameter.
+	MemberParameter = "parameter"
+	// MemberMeta is the member of an error object
+	// containing non-standard meta information.
+	MemberMeta = "meta"
+)
+
+// ErrorSource references the source of the problem
+// described by a JSON API error object.
+type ErrorSource struct {
+	// Pointer is a JSON Pointer to the value in the
+	// request document causing the problem,
+	// i.e. "/data/attributes/title".
+	Pointer string
+	// Parameter names the query parameter
+	// causing the problem.
+	Parameter string
+}
+
+// Error is a type implementing the `error` interface,
+// representing a JSON API error object.
+type Error struct {
+	ID     string
+	Status string
+	Code   string
+	Title  string
+	Detail string
+	Source ErrorSource
+	Meta   map[string]interface{}
+}
+
+// Error implements the `error` interface for the
+// Error type, returning its Detail, or its Title
+// if it has no Detail.
+func (e Error) Error() string {
+	if 0 < len(e.Detail) {
+		return e.Detail
+	}
+
+	return e.Title
+}
+
+// ErrorObjects provides an interface for errors
+// describing themselves as JSON API error objects.
+type ErrorObjects interface {
+	// ErrorObjects returns the JSON API error
+	// objects describing the error.
+	ErrorObjects() []Error
+}
+
+// ErrorAdapter provides an interface for converting errors
+// not implementing the ErrorObjects interface into JSON
+// API error objects.
+type ErrorAdapter interface {
+	// AdaptError returns the JSON API error
+	// objects describing the error `e`.
+	AdaptError(e error) []Error
+}
+
+// ErrorAdapterFunc is an adapter allowing the use of
+// ordinary functions as implementations of the
+// ErrorAdapter interface.
+type ErrorAdapterFunc func(e error) []Error
+
+// AdaptError implements the ErrorAdapter interface
+// for the ErrorAdapterFunc type.
+func (f ErrorAdapterFunc) AdaptError(e error) []Error {
+	return f(e)
+}
+
+// DefaultErrorTitle is the title of the error objects
+// produced by DefaultErrorAdapter.
+const DefaultErrorTitle = "Internal Error"
+
+// DefaultErrorAdapter is the ErrorAdapter used when none is
+// provided, describing errors by DefaultErrorTitle alone.
+// Error messages may carry internal details, such as the
+// values being serialized, so they are never included;
+// provide an ErrorAdapter to describe errors in detail.
+var DefaultErrorAdapter ErrorAdapter = ErrorAdapterFunc(func(e error) []Error {
+	return []Error{Error{Title: DefaultErrorTitle}}
+})
+
+// ErrorObjectsOf returns the JSON API error objects describing
+// the error `e`. Errors of the Error type, or pointers to it,
+// describe themselves,
+// errors wrapping an implementation of the ErrorObjects interface
+// are described by it, all others are converted with the
+// ErrorAdapter `a`, or DefaultErrorAdapter if `a` is nil.
+func ErrorObjectsOf(e error, a ErrorAdapter) []Error {
+	var (
+		object  Error
+		pointer *Error
+		objects ErrorObjects
+	)
+
+	if errors.As(e, &object) {
+		return []Error{object}
+	} else if errors.As(e, &pointer) && nil != pointer {
+		return []Error{*pointer}
+	} else if errors.As(e, &objects) {
+		return objects.ErrorObjects()
+	} else if nil == a {
+		a = DefaultErrorAdapter
+	}
+
+	return a.AdaptError(e)
+}
+
+// DefaultValidationTitle is the title of the error objects
+// produced by ValidationErrorAdapter.
+const DefaultValidationTitle = "Invalid Attribute"
+
+// FieldError is a type implementing the `error` interface,
+// describing a field of a resource that failed validation.
+type FieldError struct {
+	// Field is the formatted attribute name of the field,
+	// or its relationship name if Relationship is true.
+	Field string
+	// Relationship determines whether the field is
+	// a relationship rather than an attribute.
+	Relationship bool
+	// Err describes the failure, its message being
+	// the detail of the field's error object.
+	Err error
+}
+
+// Error implements the `error` interface for the
+// FieldError type.
+func (f FieldError) Error() string {
+	if nil == f.Err {
+		return f.Field + " is invalid"
+	}
+
+	return f.Field + ": " + f.Err.Error()
+}
+
+// Unwrap returns the error describing the
+// failure of the FieldError.
+func (f FieldError) Unwrap() error {
+	return f.Err
+}
+
+// Pointer returns the JSON Pointer to the field of the
+// FieldError within a request document's primary data,
+// i.e. "/data/attributes/title".
+func (f FieldError) Pointer() string {
+	var member = "attributes"
+
+	if f.Relationship {
+		member = "relationships"
+	}
+
+	return "/data/" + member + "/" + pointerEscaper.Replace(f.Field)
+}
+
+// pointerEscaper escapes reference tokens
+// of JSON Pointers as RFC 6901 requires.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// ValidationError is a type implementing the `error`
+// interface, describing each field of a resource
+// that failed validation.
+type ValidationError []FieldError
+
+// Error implements the `error` interface for the
+// ValidationError type.
+func (v ValidationError) Error() string {
+	var messages = make([]string, 0, len(v))
+
+	for i := 0; i < len(v); i++ {
+		messages = append(messages, v[i].Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// ValidationErrorAdapter is a type implementing the ErrorAdapter
+// interface, describing each FieldError of a ValidationError, or
+// a FieldError alone, by an error object with the field's JSON
+// Pointer as its source and its failure as its detail. All other
+// errors are converted with Fallback.
+type ValidationErrorAdapter struct {
+	// Fallback converts errors other than validation
+	// errors. If nil, DefaultErrorAdapter is used.
+	Fallback ErrorAdapter
+}
+
+// AdaptError implements the ErrorAdapter interface
+// for the ValidationErrorAdapter type.
+func (v ValidationErrorAdapter) AdaptError(e error) []Error {
+	var (
+		invalid ValidationError
+		field   FieldError
+	)
+
+	if errors.As(e, &invalid) {
+		var objects = make([]Error, 0, len(invalid))
+
+		for i := 0; i < len(invalid); i++ {
+			objects = append(objects, v.AdaptField(invalid[i]))
+		}
+
+		return objects
+	} else if errors.As(e, &field) {
+		return []Error{v.AdaptField(field)}
+	} else if nil == v.Fallback {
+		return DefaultErrorAdapter.AdaptError(e)
+	}
+
+	return v.Fallback.AdaptError(e)
+}
+
+// AdaptField returns the error object describing
+// the field that failed validation `f`.
+func (v ValidationErrorAdapter) AdaptField(f FieldError) Error {
+	var object = Error{
+		Title:  DefaultValidationTitle,
+		Source: ErrorSource{Pointer: f.Pointer()},
+	}
+
+	if nil != f.Err {
+		object.Detail = f.Err.Error()
+	}
+
+	return object
+}
+
+// ErrorSerializer is a type implementing the Serializer
+// interface, producing JSON API documents containing the
+// error objects describing one or many errors under the
+// top level member `errors`.
+type ErrorSerializer struct {
+	// ErrorAdapter converts errors not implementing the
+	// ErrorObjects interface into JSON API error objects.
+	// If nil, DefaultErrorAdapter is used.
+	ErrorAdapter ErrorAdapter
+}
+
+// Accept implements the `Accept` method required by the
+// Serializer interface, accepting an error or a slice
+// of errors, including slices of the Error type.
+func (e *ErrorSerializer) Accept(i interface{}, options ...Option) (map[string]interface{}, error) {
+	var (
+		value   = reflect.ValueOf(i)
+		objects = make([]interface{}, 0, 0)
+	)
+
+	if err, ok := i.(error); ok {
+		for _, object := range ErrorObjectsOf(err, e.ErrorAdapter) {
+			objects = append(objects, e.SerializeError(object))
+		}
+	} else if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		for j := 0; j < value.Len(); j++ {
+			var err, ok = value.Index(j).Interface().(error)
+
+			if !ok {
+				return nil, UnsupportedKindError{value.Index(j).Kind(), e}
+			}
+
+			for _, object := range ErrorObjectsOf(err, e.ErrorAdapter) {
+				objects = append(objects, e.SerializeError(object))
+			}
+		}
+	} else {
+		return nil, UnsupportedKindError{value.Kind(), e}
+	}
+
+	return map[string]interface{}{MemberErrors: objects}, nil
+}
+
+// AcceptContext implements the `AcceptContext` method required
+// by the Serializer interface, serializing as Accept does
+// unless `ctx` is already done.
+func (e *ErrorSerializer) AcceptContext(ctx context.Context, i interface{}, options ...Option) (map[string]interface{}, error) {
+	if err := ctx.Err(); nil != err {
+		return nil, err
+	}
+
+	return e.Accept(i, options...)
+}
+
+// SerializeError serializes the Error `o` into a JSON API
+// error object, omitting members without a value.
+func (e *ErrorSerializer) SerializeError(o Error) map[string]interface{} {
+	var (
+		object = make(map[string]interface{})
+		source = make(map[string]interface{})
+		fields = [][2]string{
+			{MemberID, o.ID},
+			{MemberStatus, o.Status},
+			{MemberCode, o.Code},
+			{MemberTitle, o.Title},
+			{MemberDetail, o.Detail},
+		}
+	)
+
+	for i := 0; i < len(fields); i++ {
+		if 0 < len(fields[i][1]) {
+			object[fields[i][0]] = fields[i][1]
+		}
+	}
+
+	if 0 < len(o.Source.Pointer) {
+		source[MemberPointer] = o.Source.Pointer
+	}
+
+	if 0 < len(o.Source.Parameter) {
+		source[MemberParameter] = o.Source.Parameter
+	}
+
+	if 0 < len(source) {
+		object[MemberSource] = source
+	}
+
+	if 0 < len(o.Meta) {
+		object[MemberMeta] = o.Meta
+	}
+
+	return object
+}
@@ -0,0 +1,183 @@
+package serializers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type TValidationError struct {
+	Field string
+}
+
+func (t TValidationError) Error() string {
+	return t.Field + " is invalid"
+}
+
+func (t TValidationError) ErrorObjects() []serializers.Error {
+	return []serializers.Error{serializers.Error{
+		Status: "422",
+		Detail: t.Error(),
+		Source: serializers.ErrorSource{Pointer: "/data/attributes/" + t.Field},
+	}}
+}
+
+type TPointerError struct {
+	Err *serializers.Error
+}
+
+func (t TPointerError) Error() string {
+	return "wrapped: " + t.Err.Error()
+}
+
+func (t TPointerError) Unwrap() error {
+	return t.Err
+}
+
+func TestError(t *testing.T) {
+	assert.Equal(t, "detail", serializers.Error{Title: "title", Detail: "detail"}.Error(), "failed to return Detail as error message")
+	assert.Equal(t, "title", serializers.Error{Title: "title"}.Error(), "failed to return Title as error message")
+}
+
+func TestErrorAdapterFuncImplementation(t *testing.T) {
+	var f = serializers.ErrorAdapterFunc(func(e error) []serializers.Error { return nil })
+	assert.Implements(t, (*serializers.ErrorAdapter)(nil), f, "ErrorAdapterFunc failed to implement ErrorAdapter interface")
+}
+
+func TestErrorObjectsOf(t *testing.T) {
+	var (
+		object  = serializers.Error{Code: "E1", Title: "failed"}
+		adapter = serializers.ErrorAdapterFunc(func(e error) []serializers.Error {
+			return []serializers.Error{serializers.Error{Status: "500", Detail: e.Error()}}
+		})
+	)
+
+	assert.Equal(t, []serializers.Error{object}, serializers.ErrorObjectsOf(object, nil), "failed to describe Error by itself")
+	assert.Equal(t, []serializers.Error{object}, serializers.ErrorObjectsOf(fmt.Errorf("wrapped: %w", object), nil), "failed to describe wrapped Error by itself")
+	assert.Equal(t, TValidationError{"title"}.ErrorObjects(), serializers.ErrorObjectsOf(fmt.Errorf("wrapped: %w", TValidationError{"title"}), nil), "failed to describe error with its ErrorObjects method")
+	assert.Equal(t, []serializers.Error{serializers.Error{Title: serializers.DefaultErrorTitle}}, serializers.ErrorObjectsOf(errors.New("failed"), nil), "failed to describe error with DefaultErrorAdapter")
+	assert.Equal(t, []serializers.Error{serializers.Error{Status: "500", Detail: "failed"}}, serializers.ErrorObjectsOf(errors.New("failed"), adapter), "failed to describe error with ErrorAdapter")
+	assert.Equal(t, []serializers.Error{object}, serializers.ErrorObjectsOf(TPointerError{&object}, nil), "failed to describe wrapped *Error by itself")
+}
+
+func TestValidationErrorAdapter(t *testing.T) {
+	var (
+		adapter = serializers.ValidationErrorAdapter{}
+		invalid = serializers.ValidationError{
+			{Field: "title", Err: errors.New("is required")},
+			{Field: "a/b~c", Err: errors.New("is too long")},
+			{Field: "author", Relationship: true},
+		}
+	)
+
+	assert.Implements(t, (*serializers.ErrorAdapter)(nil), adapter, "ValidationErrorAdapter failed to implement ErrorAdapter interface")
+	assert.Equal(t, "title: is required; a/b~c: is too long; author is invalid", invalid.Error(), "failed to join messages of ValidationError")
+	assert.Equal(t, []serializers.Error{
+		serializers.Error{Title: serializers.DefaultValidationTitle, Detail: "is required", Source: serializers.ErrorSource{Pointer: "/data/attributes/title"}},
+		serializers.Error{Title: serializers.DefaultValidationTitle, Detail: "is too long", Source: serializers.ErrorSource{Pointer: "/data/attributes/a~1b~0c"}},
+		serializers.Error{Title: serializers.DefaultValidationTitle, Source: serializers.ErrorSource{Pointer: "/data/relationships/author"}},
+	}, serializers.ErrorObjectsOf(fmt.Errorf("wrapped: %w", invalid), adapter), "failed to describe each field of ValidationError")
+	assert.Equal(t, []serializers.Error{
+		serializers.Error{Title: serializers.DefaultValidationTitle, Detail: "is required", Source: serializers.ErrorSource{Pointer: "/data/attributes/title"}},
+	}, serializers.ErrorObjectsOf(invalid[0], adapter), "failed to describe FieldError")
+	assert.Equal(t, []serializers.Error{serializers.Error{Title: serializers.DefaultErrorTitle}}, serializers.ErrorObjectsOf(errors.New("failed"), adapter), "failed to describe other errors with DefaultErrorAdapter")
+
+	adapter.Fallback = serializers.ErrorAdapterFunc(func(e error) []serializers.Error {
+		return []serializers.Error{serializers.Error{Detail: e.Error()}}
+	})
+
+	assert.Equal(t, []serializers.Error{serializers.Error{Detail: "failed"}}, serializers.ErrorObjectsOf(errors.New("failed"), adapter), "failed to describe other errors with Fallback")
+}
+
+func TestErrorSerializerAccept(t *testing.T) {
+	var (
+		serializer  = &serializers.ErrorSerializer{}
+		result, err = serializer.Accept([]error{
+			serializers.Error{
+				ID:     "1",
+				Status: "400",
+				Code:   "E1",
+				Title:  "Invalid Query Parameter",
+				Detail: "sort is invalid",
+				Source: serializers.ErrorSource{Parameter: "sort"},
+				Meta:   map[string]interface{}{"retry": false},
+			},
+			TValidationError{"title"},
+			errors.New("failed"),
+		})
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{"errors": [
+		{"id": "1", "status": "400", "code": "E1", "title": "Invalid Query Parameter", "detail": "sort is invalid", "source": {"parameter": "sort"}, "meta": {"retry": false}},
+		{"status": "422", "detail": "title is invalid", "source": {"pointer": "/data/attributes/title"}},
+		{"title": "Internal Error"}
+	]}`, string(encoded), "failed to produce JSON API error document")
+}
+
+func TestErrorSerializerAcceptSingle(t *testing.T) {
+	var result, err = (&serializers.ErrorSerializer{}).Accept(errors.New("failed"))
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, map[string]interface{}{"errors": []interface{}{map[string]interface{}{"title": "Internal Error"}}}, result, "failed to produce JSON API error document")
+
+	result, err = (&serializers.ErrorSerializer{}).Accept([]serializers.Error{serializers.Error{Title: "failed"}})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, map[string]interface{}{"errors": []interface{}{map[string]interface{}{"title": "failed"}}}, result, "failed to accept slice of Error")
+}
+
+func TestErrorSerializerHidesMessages(t *testing.T) {
+	var (
+		internal = fmt.Errorf("wrapped: %w", serializers.UnlinkedResourceError{Value: reflect.ValueOf(struct{ Password string }{"hunter2"})})
+		adapter  = serializers.ErrorAdapterFunc(func(e error) []serializers.Error {
+			return []serializers.Error{serializers.Error{Detail: e.Error()}}
+		})
+		result, err = (&serializers.ErrorSerializer{}).Accept(internal)
+		encoded, _  = json.Marshal(result)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.NotContains(t, string(encoded), "hunter2", "rendered message of error without ErrorAdapter")
+
+	result, _ = (&serializers.ErrorSerializer{ErrorAdapter: adapter}).Accept(internal)
+	encoded, _ = json.Marshal(result)
+
+	assert.Contains(t, string(encoded), "hunter2", "failed to render message of error with ErrorAdapter")
+}
+
+func TestErrorSerializerAcceptUnsupported(t *testing.T) {
+	var _, err = (&serializers.ErrorSerializer{}).Accept("failed")
+
+	assert.IsType(t, serializers.UnsupportedKindError{}, err, "failed to return serializers.UnsupportedKindError")
+
+	_, err = (&serializers.ErrorSerializer{}).Accept([]string{"failed"})
+
+	assert.IsType(t, serializers.UnsupportedKindError{}, err, "failed to return serializers.UnsupportedKindError")
+}
+
+func TestErrorSerializerAcceptContext(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		result, err = (&serializers.ErrorSerializer{}).AcceptContext(ctx, errors.New("failed"))
+	)
+
+	assert.Nil(t, err, "received unexpected error from AcceptContext")
+	assert.Len(t, result[serializers.MemberErrors], 1, "failed to serialize error")
+
+	cancel()
+
+	_, err = (&serializers.ErrorSerializer{}).AcceptContext(ctx, errors.New("failed"))
+	assert.Equal(t, context.Canceled, err, "failed to return error of done context")
+}
@@ -0,0 +1,73 @@
+package serializers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldError occurs when a sparse fieldset names
+// an attribute that resources of its type do not have
+// and strict fieldsets have been requested.
+type UnknownFieldError struct {
+	Type  string
+	Field string
+}
+
+// Error implements the `error` interface for the
+// UnknownFieldError type.
+func (u UnknownFieldError) Error() string {
+	return fmt.Sprintf("sparse fieldset for type `%s` contains unknown field `%s`", u.Type, u.Field)
+}
+
+// Fieldsets maps formatted type names to the attribute
+// names serialized for resources of that type, the sparse
+// fieldsets of the JSON API `fields[type]` query parameter.
+// Resources of types without a fieldset have every
+// attribute serialized.
+type Fieldsets map[string]map[string]struct{}
+
+// Add adds the attribute names `n` to the fieldset of
+// the formatted type name `t`. Each name may contain
+// several comma separated attribute names, i.e.
+// "title,author".
+func (f Fieldsets) Add(t string, n ...string) {
+	var fieldset, ok = f[t]
+
+	if !ok {
+		fieldset = make(map[string]struct{})
+		f[t] = fieldset
+	}
+
+	for i := 0; i < len(n); i++ {
+		var names = strings.Split(n[i], IncludeSeparator)
+
+		for j := 0; j < len(names); j++ {
+			if name := strings.TrimSpace(names[j]); 0 < len(name) {
+				fieldset[name] = struct{}{}
+			}
+		}
+	}
+}
+
+// CheckFieldset returns an UnknownFieldError for the first
+// attribute name, in sorted order, of the fieldset `f` for
+// resources of the formatted type name `t` not contained
+// in the attribute names `n` of the type.
+func CheckFieldset(t string, f, n map[string]struct{}) error {
+	var names = make([]string, 0, len(f))
+
+	for name := range f {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for i := 0; i < len(names); i++ {
+		if _, ok := n[names[i]]; !ok {
+			return UnknownFieldError{t, names[i]}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package serializers_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownFieldError(t *testing.T) {
+	var err = serializers.UnknownFieldError{"posts", "body"}
+
+	assert.Equal(t, "sparse fieldset for type `posts` contains unknown field `body`", err.Error(), "failed to return correct error message for UnknownFieldError")
+}
+
+func TestFieldsetsAdd(t *testing.T) {
+	var fieldsets = make(serializers.Fieldsets)
+
+	fieldsets.Add("posts", "title, author", "comments")
+	fieldsets.Add("persons", "")
+
+	assert.Equal(t, serializers.Fieldsets{
+		"posts":   map[string]struct{}{"title": struct{}{}, "author": struct{}{}, "comments": struct{}{}},
+		"persons": map[string]struct{}{},
+	}, fieldsets, "failed to add attribute names to fieldsets")
+}
+
+func TestFieldset(t *testing.T) {
+	type Post struct {
+		ID     int
+		Title  string
+		Body   string
+		Secret string `tranq:"-"`
+	}
+
+	var (
+		serializer = serializers.Base{State: serializers.State{Fieldsets: serializers.Fieldsets{"Post": {"Title": struct{}{}}}}}
+		typ        = reflect.TypeOf(Post{})
+	)
+
+	var fieldset, sparse, err = serializer.Fieldset(typ)
+
+	assert.Nil(t, err, "received unexpected error from Fieldset")
+	assert.True(t, sparse, "failed to report sparse fieldset")
+	assert.Contains(t, fieldset, "Title", "failed to return sparse fieldset")
+
+	_, sparse, _ = serializer.Fieldset(reflect.TypeOf(Friend{}))
+	assert.False(t, sparse, "reported sparse fieldset for type without fieldset")
+
+	serializer.StrictFields = true
+	serializer.Fieldsets.Add("Post", "Secret")
+
+	_, _, err = serializer.Fieldset(typ)
+	assert.Equal(t, serializers.UnknownFieldError{"Post", "Secret"}, err, "failed to reject ignored field")
+}
+
+func TestAcceptFields(t *testing.T) {
+	type Post struct {
+		ID     int
+		Title  string
+		Body   string
+		Author Friend `tranq_link:"true"`
+	}
+
+	var serializer = serializers.Base{}
+
+	serializer.ReservedStrings.ID = "id"
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+	serializer.ReservedStrings.Type = "type"
+
+	var result, err = serializer.Accept(Post{1, "Lorem", "Ipsum", Friend{ID: 2, Name: "Jon"}},
+		serializers.Fields("Post", "Title,Author"),
+		serializers.Fields("Friend", "Friend"),
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, map[string]interface{}{
+		"id":    1,
+		"Title": "Lorem",
+		"links": map[string]interface{}{
+			"Author": map[string]interface{}{"id": 2, "type": "Friend"},
+		},
+	}, result["Post"], "failed to apply sparse fieldset to primary data")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"id":    2,
+		"links": map[string]interface{}{"Friend": nil},
+	}}, result["linked"].(map[string]interface{})["Friend"], "failed to apply sparse fieldset to linked documents")
+
+	_, err = serializer.Accept(Post{ID: 1}, serializers.Fields("Post", "Title,Summary"), serializers.StrictFields())
+	assert.Equal(t, serializers.UnknownFieldError{"Post", "Summary"}, err, "failed to return serializers.UnknownFieldError")
+}
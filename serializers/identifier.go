@@ -0,0 +1,166 @@
+package serializers
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	// IdentifierTagName represents the struct tag name
+	// marking a field as a resource's identifier when
+	// using the TagIdentifier IdentifierStrategy,
+	// i.e. `tranq:"id"`.
+	IdentifierTagName = "id"
+)
+
+// DefaultIdentifierStrategy is the IdentifierStrategy used
+// when none is provided, locating identifiers in the field
+// named by the constant string contained in ID.
+var DefaultIdentifierStrategy IdentifierStrategy = FieldIdentifier(ID)
+
+// Identifiable is the interface implemented by types
+// providing their own identifier, used by the
+// MethodIdentifier IdentifierStrategy.
+type Identifiable interface {
+	// Identifier returns the identifier of the resource.
+	Identifier() interface{}
+}
+
+// IdentifierStrategy provides an interface for locating
+// the identifier of resources during serialization and
+// deserialization.
+type IdentifierStrategy interface {
+	// IdentifierField returns the struct field of type `t`
+	// containing the identifier, reporting false if the
+	// identifier is not stored in a field.
+	IdentifierField(t reflect.Type) (reflect.StructField, bool)
+	// Identifier returns the identifier of the struct value
+	// `v`, reporting false if it has none.
+	Identifier(v reflect.Value) (interface{}, bool)
+}
+
+// FieldIdentifier is an IdentifierStrategy locating identifiers
+// in the struct field with the name it contains.
+type FieldIdentifier string
+
+// IdentifierField implements the IdentifierStrategy interface
+// for the FieldIdentifier type.
+func (f FieldIdentifier) IdentifierField(t reflect.Type) (reflect.StructField, bool) {
+	return t.FieldByName(string(f))
+}
+
+// Identifier implements the IdentifierStrategy interface
+// for the FieldIdentifier type.
+func (f FieldIdentifier) Identifier(v reflect.Value) (interface{}, bool) {
+	return fieldIdentifier(f, v)
+}
+
+// TagIdentifier is an IdentifierStrategy locating identifiers
+// in the first struct field whose struct tag, with the key it
+// contains, is named by the constant string contained in
+// IdentifierTagName, i.e. TagIdentifier(Tranq) locates fields
+// tagged `tranq:"id"`.
+type TagIdentifier string
+
+// IdentifierField implements the IdentifierStrategy interface
+// for the TagIdentifier type.
+func (t TagIdentifier) IdentifierField(typ reflect.Type) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		var field = typ.Field(i)
+
+		if IdentifierTagName == parseTag(field, string(t)).Name {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// Identifier implements the IdentifierStrategy interface
+// for the TagIdentifier type.
+func (t TagIdentifier) Identifier(v reflect.Value) (interface{}, bool) {
+	return fieldIdentifier(t, v)
+}
+
+// MethodIdentifier is an IdentifierStrategy locating identifiers
+// through the `Identifier` method of types implementing the
+// Identifiable interface, deferring to its Fallback
+// IdentifierStrategy for all other types.
+type MethodIdentifier struct {
+	// Fallback is the IdentifierStrategy used for types
+	// not implementing the Identifiable interface, and
+	// to locate the field populated during deserialization.
+	Fallback IdentifierStrategy
+}
+
+// IdentifierField implements the IdentifierStrategy interface
+// for the MethodIdentifier type.
+func (m MethodIdentifier) IdentifierField(t reflect.Type) (reflect.StructField, bool) {
+	if nil == m.Fallback {
+		return reflect.StructField{}, false
+	}
+
+	return m.Fallback.IdentifierField(t)
+}
+
+// Identifier implements the IdentifierStrategy interface
+// for the MethodIdentifier type.
+func (m MethodIdentifier) Identifier(v reflect.Value) (interface{}, bool) {
+	if identifiable, ok := v.Interface().(Identifiable); ok {
+		return identifiable.Identifier(), true
+	}
+
+	var ptr = reflect.New(v.Type())
+	ptr.Elem().Set(v)
+
+	if identifiable, ok := ptr.Interface().(Identifiable); ok {
+		return identifiable.Identifier(), true
+	} else if nil == m.Fallback {
+		return nil, false
+	}
+
+	return m.Fallback.Identifier(v)
+}
+
+// fieldIdentifier returns the value of the struct field
+// located by the IdentifierStrategy `s` within the struct
+// value `v`.
+func fieldIdentifier(s IdentifierStrategy, v reflect.Value) (interface{}, bool) {
+	var field, ok = s.IdentifierField(v.Type())
+
+	if !ok {
+		return nil, false
+	}
+
+	var value, err = v.FieldByIndexErr(field.Index)
+
+	if nil != err || !value.CanInterface() {
+		return nil, false
+	}
+
+	return value.Interface(), true
+}
+
+// describeIdentifierStrategy describes where the IdentifierStrategy
+// `s`, or DefaultIdentifierStrategy if nil, locates identifiers,
+// i.e. "field `ID`".
+func describeIdentifierStrategy(s IdentifierStrategy) string {
+	if nil == s {
+		s = DefaultIdentifierStrategy
+	}
+
+	switch strategy := s.(type) {
+	case FieldIdentifier:
+		return fmt.Sprintf("field `%s`", string(strategy))
+	case TagIdentifier:
+		return fmt.Sprintf("field tagged `%s:\"%s\"`", string(strategy), IdentifierTagName)
+	case MethodIdentifier:
+		if nil == strategy.Fallback {
+			return "method `Identifier`"
+		}
+
+		return "method `Identifier` or " + describeIdentifierStrategy(strategy.Fallback)
+	}
+
+	return fmt.Sprintf("located by `%T`", s)
+}
@@ -0,0 +1,117 @@
+package serializers_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Keyed struct {
+	Key  string `tranq:"id"`
+	Name string
+}
+
+type Method struct {
+	Name string
+}
+
+func (m Method) Identifier() interface{} {
+	return m.Name
+}
+
+func TestFieldIdentifier(t *testing.T) {
+	type Cased struct {
+		Id   int
+		Name string
+	}
+
+	var (
+		strategy  = serializers.FieldIdentifier("Id")
+		value     = reflect.ValueOf(Cased{1, "Jon"})
+		field, ok = strategy.IdentifierField(value.Type())
+		id, found = strategy.Identifier(value)
+	)
+
+	assert.True(t, ok, "failed to locate identifier field")
+	assert.Equal(t, "Id", field.Name, "failed to locate identifier field by name")
+	assert.True(t, found, "failed to locate identifier")
+	assert.Equal(t, 1, id, "failed to return identifier")
+
+	_, found = strategy.Identifier(reflect.ValueOf(Method{}))
+	assert.False(t, found, "reported identifier for type without identifier field")
+}
+
+func TestTagIdentifier(t *testing.T) {
+	var (
+		strategy  = serializers.TagIdentifier(serializers.Tranq)
+		value     = reflect.ValueOf(Keyed{"abc", "Jon"})
+		field, ok = strategy.IdentifierField(value.Type())
+		id, found = strategy.Identifier(value)
+	)
+
+	assert.True(t, ok, "failed to locate identifier field")
+	assert.Equal(t, "Key", field.Name, "failed to locate identifier field by struct tag")
+	assert.True(t, found, "failed to locate identifier")
+	assert.Equal(t, "abc", id, "failed to return identifier")
+
+	_, found = strategy.Identifier(reflect.ValueOf(Method{}))
+	assert.False(t, found, "reported identifier for type without tagged field")
+}
+
+func TestMethodIdentifier(t *testing.T) {
+	var (
+		strategy  = serializers.MethodIdentifier{}
+		id, found = strategy.Identifier(reflect.ValueOf(Method{"jon"}))
+	)
+
+	assert.True(t, found, "failed to locate identifier")
+	assert.Equal(t, "jon", id, "failed to return identifier from Identifier method")
+
+	_, found = strategy.Identifier(reflect.ValueOf(Keyed{"abc", "Jon"}))
+	assert.False(t, found, "reported identifier without Fallback IdentifierStrategy")
+
+	strategy.Fallback = serializers.TagIdentifier(serializers.Tranq)
+	id, found = strategy.Identifier(reflect.ValueOf(Keyed{"abc", "Jon"}))
+
+	assert.True(t, found, "failed to defer to Fallback IdentifierStrategy")
+	assert.Equal(t, "abc", id, "failed to return identifier from Fallback IdentifierStrategy")
+}
+
+func TestSerializeIdentifierStrategy(t *testing.T) {
+	type Author struct {
+		Key  string `tranq:"id"`
+		Name string
+	}
+
+	type Book struct {
+		Key    string `tranq:"id"`
+		Title  string
+		Author Author `tranq_link:"true"`
+	}
+
+	var serializer = serializers.Base{
+		IdentifierStrategy: serializers.TagIdentifier(serializers.Tranq),
+	}
+
+	serializer.ReservedStrings.ID = "id"
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+
+	var result, err = serializer.Accept(Book{"b1", "Lorem", Author{"a1", "Jon"}})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var (
+		book    = result["Book"].(map[string]interface{})
+		links   = book["links"].(map[string]interface{})
+		details = links["Author"].(map[string]interface{})
+	)
+
+	assert.Equal(t, "b1", book["id"], "failed to serialize identifier located by IdentifierStrategy")
+	assert.NotContains(t, book, "Key", "serialized identifier field as an attribute")
+	assert.Equal(t, "a1", details["id"], "failed to link resource by identifier located by IdentifierStrategy")
+}
@@ -0,0 +1,70 @@
+package serializers
+
+import (
+	"strings"
+)
+
+const (
+	// IncludeSeparator separates relationship paths
+	// within an include specification.
+	IncludeSeparator = ","
+	// PathSeparator separates the relationship names
+	// of a single relationship path.
+	PathSeparator = "."
+)
+
+// IncludePaths is a tree of relationship paths determining
+// which linked resources are sideloaded into a document,
+// keyed by formatted attribute name. A nil IncludePaths
+// sideloads every compound document, an empty one none.
+type IncludePaths map[string]IncludePaths
+
+// ParseIncludePaths parses an include specification in the
+// format of the JSON API `include` query parameter, i.e.
+// "author,comments.author", into an IncludePaths tree.
+// Every relationship along a path is included.
+func ParseIncludePaths(s string) IncludePaths {
+	var (
+		paths = make(IncludePaths)
+		specs = strings.Split(s, IncludeSeparator)
+	)
+
+	for i := 0; i < len(specs); i++ {
+		var spec = strings.TrimSpace(specs[i])
+
+		if 0 == len(spec) {
+			continue
+		}
+
+		var (
+			names = strings.Split(spec, PathSeparator)
+			node  = paths
+		)
+
+		for j := 0; j < len(names); j++ {
+			var child, ok = node[names[j]]
+
+			if !ok {
+				child = make(IncludePaths)
+				node[names[j]] = child
+			}
+
+			node = child
+		}
+	}
+
+	return paths
+}
+
+// Relationship reports whether the relationship named `n`
+// is included, returning the IncludePaths of its own
+// relationships.
+func (p IncludePaths) Relationship(n string) (IncludePaths, bool) {
+	if nil == p {
+		return nil, true
+	}
+
+	var child, ok = p[n]
+
+	return child, ok
+}
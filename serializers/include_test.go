@@ -0,0 +1,40 @@
+package serializers_test
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIncludePaths(t *testing.T) {
+	var expected = serializers.IncludePaths{
+		"author": serializers.IncludePaths{},
+		"comments": serializers.IncludePaths{
+			"author": serializers.IncludePaths{},
+		},
+	}
+
+	assert.Equal(t, expected, serializers.ParseIncludePaths("author,comments.author"), "failed to parse include specification")
+	assert.Equal(t, expected, serializers.ParseIncludePaths(" comments.author , author,,"), "failed to ignore whitespace and empty paths")
+	assert.Equal(t, serializers.IncludePaths{}, serializers.ParseIncludePaths(""), "failed to parse empty include specification")
+}
+
+func TestIncludePathsRelationship(t *testing.T) {
+	var (
+		paths                = serializers.ParseIncludePaths("comments.author")
+		comments, included   = paths.Relationship("comments")
+		author, authored     = comments.Relationship("author")
+		_, editor            = paths.Relationship("editor")
+		all, includedFromNil = serializers.IncludePaths(nil).Relationship("editor")
+	)
+
+	assert.True(t, included, "failed to include relationship along path")
+	assert.True(t, authored, "failed to include nested relationship along path")
+	assert.Empty(t, author, "failed to return relationships of included relationship")
+	assert.False(t, editor, "included relationship not along path")
+	assert.True(t, includedFromNil, "failed to include every relationship for nil IncludePaths")
+	assert.Nil(t, all, "failed to include every nested relationship for nil IncludePaths")
+}
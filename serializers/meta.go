@@ -0,0 +1,128 @@
+package serializers
+
+import (
+	"context"
+	"reflect"
+)
+
+const (
+	// TranqMeta represents the struct tag marking a field
+	// whose value is serialized into the meta information
+	// of its resource rather than as an attribute.
+	TranqMeta = "tranq_meta"
+)
+
+var (
+	metaProvider        = reflect.TypeOf((*MetaProvider)(nil)).Elem()
+	contextMetaProvider = reflect.TypeOf((*ContextMetaProvider)(nil)).Elem()
+)
+
+// MetaProvider provides an interface for types
+// supplying meta information for their resources.
+type MetaProvider interface {
+	// TranqMeta returns the meta information
+	// of the resource.
+	TranqMeta() map[string]interface{}
+}
+
+// ContextMetaProvider provides an interface for types supplying
+// meta information for their resources with the context.Context
+// of the call to AcceptContext in progress. Serializers prefer it
+// to the MetaProvider interface when a type implements both.
+type ContextMetaProvider interface {
+	// TranqMetaContext returns the meta
+	// information of the resource.
+	TranqMetaContext(ctx context.Context) map[string]interface{}
+}
+
+// IsMeta returns true if the struct field `f` is marked
+// with the TranqMeta struct tag.
+func IsMeta(f reflect.StructField) bool {
+	return "true" == f.Tag.Get(TranqMeta)
+}
+
+// Meta returns an Option adding the members of `m` to
+// the top level meta information of a document, i.e.
+// total counts or request identifiers.
+func Meta(m map[string]interface{}) Option {
+	return func(o *Options) {
+		if nil == o.Meta {
+			o.Meta = make(map[string]interface{})
+		}
+
+		for key, value := range m {
+			o.Meta[key] = value
+		}
+	}
+}
+
+// ResourceMeta returns the meta information of the struct
+// value `v`, collected from fields marked with the TranqMeta
+// struct tag and the ContextMetaProvider or MetaProvider
+// interface, in that order.
+// Tagged fields serializing to objects have their members
+// merged, all others not serializing to nil are keyed
+// by their attribute name.
+func (b *Base) ResourceMeta(v reflect.Value) (map[string]interface{}, error) {
+	var (
+		meta     = make(map[string]interface{})
+		metadata = b.Metadata(v.Type())
+	)
+
+	for i := 0; i < len(metadata.Meta); i++ {
+		var (
+			field = &metadata.Meta[i]
+			temp  = v.Field(field.Field.Index[0])
+		)
+
+		if !temp.CanInterface() {
+			return nil, UninterfaceableValueError{temp}
+		} else if b.IsOmitted(field.Tag, temp) {
+			continue
+		}
+
+		var value, err = b.Serialize(temp.Interface())
+
+		if nil != err {
+			return nil, err
+		}
+
+		MergeMeta(meta, field.Name, value)
+	}
+
+	var provider interface{}
+
+	if metadata.MetaProvider {
+		provider = v.Interface()
+	} else if metadata.MetaProviderPtr {
+		var ptr = reflect.New(v.Type())
+
+		ptr.Elem().Set(v)
+		provider = ptr.Interface()
+	}
+
+	var members map[string]interface{}
+
+	if contextual, ok := provider.(ContextMetaProvider); ok {
+		members = contextual.TranqMetaContext(b.Context())
+	} else if plain, ok := provider.(MetaProvider); ok {
+		members = plain.TranqMeta()
+	}
+
+	for key, value := range members {
+		meta[key] = value
+	}
+
+	return meta, nil
+}
+
+// MergeMeta adds the serialized value `v` of the field with
+// the attribute name `n` marked with the TranqMeta struct tag
+// to the meta information `m`, as ResourceMeta does.
+func MergeMeta(m map[string]interface{}, n string, v interface{}) {
+	if members, ok := v.(map[string]interface{}); ok {
+		mergeDocument(m, members)
+	} else if nil != v {
+		m[n] = v
+	}
+}
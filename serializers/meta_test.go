@@ -0,0 +1,122 @@
+package serializers_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Revision struct {
+	ID      int
+	Title   string
+	Version int               `tranq_meta:"true"`
+	Extra   map[string]string `tranq_meta:"true"`
+}
+
+func (r *Revision) TranqMeta() map[string]interface{} {
+	return map[string]interface{}{"stale": 1 < r.Version}
+}
+
+type Draft struct {
+	ID     int
+	Calls  *int               `tranq:"-"`
+	Cancel context.CancelFunc `tranq:"-"`
+}
+
+func (d Draft) TranqMetaContext(ctx context.Context) map[string]interface{} {
+	if nil != d.Calls {
+		*d.Calls++
+	}
+
+	if nil != d.Cancel {
+		d.Cancel()
+	}
+
+	return map[string]interface{}{"key": ctx.Value(TContextKey{})}
+}
+
+func drafts(n int, cancel context.CancelFunc, calls *int) []Draft {
+	var collection = make([]Draft, 0, n)
+
+	for i := 0; i < n; i++ {
+		collection = append(collection, Draft{i, calls, cancel})
+	}
+
+	return collection
+}
+
+func TestMeta(t *testing.T) {
+	var options = serializers.NewOptions(
+		serializers.Meta(map[string]interface{}{"total": 2}),
+		serializers.Meta(map[string]interface{}{"request": "abc"}),
+	)
+
+	assert.Equal(t, map[string]interface{}{"total": 2, "request": "abc"}, options.Meta, "failed to combine meta information")
+}
+
+func TestIsMeta(t *testing.T) {
+	var typ = reflect.TypeOf(Revision{})
+
+	assert.False(t, serializers.IsMeta(typ.Field(1)), "reported untagged field as meta")
+	assert.True(t, serializers.IsMeta(typ.Field(2)), "failed to report tagged field as meta")
+}
+
+func TestResourceMeta(t *testing.T) {
+	var (
+		serializer = new(serializers.Base)
+		value      = Revision{1, "Lorem", 2, map[string]string{"source": "import"}}
+	)
+
+	var meta, err = serializer.ResourceMeta(reflect.ValueOf(value))
+
+	assert.Nil(t, err, "received unexpected error from ResourceMeta")
+	assert.Equal(t, map[string]interface{}{
+		"Version": 2,
+		"source":  "import",
+		"stale":   true,
+	}, meta, "failed to collect resource meta information")
+}
+
+func TestAcceptMeta(t *testing.T) {
+	var serializer = new(serializers.Base)
+
+	serializer.ReservedStrings.Meta = "meta"
+
+	var result, err = serializer.Accept(Revision{ID: 1, Title: "Lorem", Version: 1},
+		serializers.Meta(map[string]interface{}{"total": 1}),
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, map[string]interface{}{
+		"Revision": map[string]interface{}{
+			"ID":    1,
+			"Title": "Lorem",
+			"meta":  map[string]interface{}{"Version": 1, "stale": false},
+		},
+		"meta": map[string]interface{}{"total": 1},
+	}, result, "failed to serialize meta information")
+}
+
+func TestResourceMetaContext(t *testing.T) {
+	var (
+		serializer = new(serializers.Base)
+		ctx        = context.WithValue(context.Background(), TContextKey{}, "key")
+	)
+
+	serializer.ReservedStrings.Meta = "meta"
+
+	var result, err = serializer.AcceptContext(ctx, Draft{ID: 1})
+
+	assert.Nil(t, err, "received unexpected error from AcceptContext")
+	assert.Equal(t, map[string]interface{}{"key": "key"}, result["Draft"].(map[string]interface{})["meta"], "failed to pass context to ContextMetaProvider")
+
+	result, err = serializer.Accept(Draft{ID: 1})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, map[string]interface{}{"key": nil}, result["Draft"].(map[string]interface{})["meta"], "failed to pass background context to ContextMetaProvider")
+}
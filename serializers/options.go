@@ -0,0 +1,105 @@
+package serializers
+
+import (
+	"strings"
+)
+
+// Options contains the settings of a single call
+// to a Serializer's `Accept` method.
+type Options struct {
+	// IncludePaths determines which linked resources
+	// are sideloaded into the document. If nil, every
+	// compound document is sideloaded.
+	IncludePaths IncludePaths
+	// Fieldsets contains the sparse fieldsets of
+	// the document, keyed by formatted type name.
+	Fieldsets Fieldsets
+	// StrictFields determines whether sparse fieldsets
+	// naming unknown attributes fail serialization.
+	StrictFields bool
+	// Meta contains the top level meta
+	// information of the document.
+	Meta map[string]interface{}
+	// Links contains the top level links
+	// of the document.
+	Links map[string]interface{}
+}
+
+// Option is a function configuring the Options
+// of a single call to a Serializer's `Accept` method.
+type Option func(*Options)
+
+// NewOptions returns the Options produced by
+// applying each Option in turn.
+func NewOptions(options ...Option) Options {
+	var o Options
+
+	for i := 0; i < len(options); i++ {
+		options[i](&o)
+	}
+
+	return o
+}
+
+// Include returns an Option restricting the linked
+// resources sideloaded into a document to the
+// relationship paths given, each an include
+// specification parsed by ParseIncludePaths.
+// Relationships not included are linked by
+// reference only.
+func Include(specs ...string) Option {
+	return IncludeTree(ParseIncludePaths(strings.Join(specs, IncludeSeparator)))
+}
+
+// IncludeTree returns an Option including the relationship
+// paths of the IncludePaths tree `p`, as Include does. The
+// tree is copied, so later changes to either it or the
+// Options do not affect the other. A nil tree leaves the
+// Options unchanged.
+func IncludeTree(p IncludePaths) Option {
+	return func(o *Options) {
+		if nil == p {
+			return
+		} else if nil == o.IncludePaths {
+			o.IncludePaths = make(IncludePaths)
+		}
+
+		mergeIncludePaths(o.IncludePaths, p)
+	}
+}
+
+// Fields returns an Option restricting the attributes
+// serialized for resources of the formatted type name `t`
+// to the names given, in the format of the JSON API
+// `fields[type]` query parameter. The type and identifier
+// of resources are always serialized.
+func Fields(t string, names ...string) Option {
+	return func(o *Options) {
+		if nil == o.Fieldsets {
+			o.Fieldsets = make(Fieldsets)
+		}
+
+		o.Fieldsets.Add(t, names...)
+	}
+}
+
+// StrictFields returns an Option failing serialization
+// with an UnknownFieldError when a sparse fieldset names
+// an attribute its type does not have.
+func StrictFields() Option {
+	return func(o *Options) {
+		o.StrictFields = true
+	}
+}
+
+// mergeIncludePaths adds copies of the relationship
+// paths of `s` to the IncludePaths tree `d`.
+func mergeIncludePaths(d, s IncludePaths) {
+	for name, child := range s {
+		if _, ok := d[name]; !ok {
+			d[name] = make(IncludePaths)
+		}
+
+		mergeIncludePaths(d[name], child)
+	}
+}
@@ -0,0 +1,57 @@
+package serializers_test
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptions(t *testing.T) {
+	assert.Nil(t, serializers.NewOptions().IncludePaths, "failed to default to nil IncludePaths")
+}
+
+func TestInclude(t *testing.T) {
+	var options = serializers.NewOptions(
+		serializers.Include("author", "comments"),
+		serializers.Include("comments.author"),
+	)
+
+	assert.Equal(t, serializers.IncludePaths{
+		"author": serializers.IncludePaths{},
+		"comments": serializers.IncludePaths{
+			"author": serializers.IncludePaths{},
+		},
+	}, options.IncludePaths, "failed to combine include specifications")
+}
+
+func TestIncludeTree(t *testing.T) {
+	var (
+		paths   = serializers.ParseIncludePaths("comments")
+		options = serializers.NewOptions(
+			serializers.Include("author"),
+			serializers.IncludeTree(paths),
+			serializers.IncludeTree(nil),
+		)
+	)
+
+	options.IncludePaths["comments"]["author"] = serializers.IncludePaths{}
+
+	assert.Equal(t, serializers.ParseIncludePaths("author,comments.author"), options.IncludePaths, "failed to merge IncludePaths tree")
+	assert.Equal(t, serializers.ParseIncludePaths("comments"), paths, "failed to copy IncludePaths tree")
+}
+
+func TestFields(t *testing.T) {
+	var options = serializers.NewOptions(
+		serializers.Fields("posts", "title,author"),
+		serializers.Fields("posts", "body"),
+		serializers.StrictFields(),
+	)
+
+	assert.Equal(t, serializers.Fieldsets{
+		"posts": map[string]struct{}{"title": struct{}{}, "author": struct{}{}, "body": struct{}{}},
+	}, options.Fieldsets, "failed to combine sparse fieldsets")
+	assert.True(t, options.StrictFields, "failed to set StrictFields")
+}
@@ -0,0 +1,304 @@
+package serializers
+
+import (
+	"net/url"
+	"strconv"
+)
+
+const (
+	// PageParameter is the query parameter family
+	// containing pagination settings, i.e. `page[size]`.
+	PageParameter = "page"
+	// PageNumber is the member of the page parameter
+	// family containing a page number.
+	PageNumber = "number"
+	// PageSize is the member of the page parameter
+	// family containing a page size.
+	PageSize = "size"
+	// PageOffset is the member of the page parameter
+	// family containing an offset.
+	PageOffset = "offset"
+	// PageLimit is the member of the page parameter
+	// family containing a limit.
+	PageLimit = "limit"
+	// PageCursor is the member of the page parameter
+	// family containing a cursor.
+	PageCursor = "cursor"
+)
+
+const (
+	// LinkFirst is the top level link to
+	// the first page of a collection.
+	LinkFirst = "first"
+	// LinkPrev is the top level link to the
+	// previous page of a collection.
+	LinkPrev = "prev"
+	// LinkNext is the top level link to
+	// the next page of a collection.
+	LinkNext = "next"
+	// LinkLast is the top level link to
+	// the last page of a collection.
+	LinkLast = "last"
+	// MetaTotal is the top level meta member containing
+	// the total number of resources in a collection.
+	MetaTotal = "total"
+)
+
+const (
+	// DefaultPageSize is the size and limit of
+	// pages requested without one.
+	DefaultPageSize = 10
+)
+
+// PageResult describes the page of a collection being
+// serialized. Paginators read only the members relevant
+// to their strategy, treating a Limit or Size of zero as
+// DefaultPageSize and a Number of zero as the first page.
+type PageResult struct {
+	// Offset and Limit describe the page
+	// for the offset strategy.
+	Offset int
+	Limit  int
+	// Number and Size describe the page for the page
+	// number strategy, with pages numbered from 1.
+	// Size also limits pages for the cursor strategy.
+	Number int
+	Size   int
+	// PrevCursor and NextCursor are the cursors of the
+	// neighbouring pages for the cursor strategy, empty
+	// if there is no such page.
+	PrevCursor string
+	NextCursor string
+	// Total is the number of resources in the collection,
+	// read only if HasTotal is true. Without a total the
+	// link to the last page is omitted and the link to the
+	// next page is always present for the offset and page
+	// number strategies.
+	Total    int
+	HasTotal bool
+}
+
+// WithTotal returns a copy of PageResult with a
+// Total of `n` resources.
+func (p PageResult) WithTotal(n int) PageResult {
+	p.Total = n
+	p.HasTotal = true
+
+	return p
+}
+
+// Defaults returns a copy of PageResult with a Limit or
+// Size of zero set to DefaultPageSize and a Number of
+// zero set to the first page.
+func (p PageResult) Defaults() PageResult {
+	if 0 >= p.Limit {
+		p.Limit = DefaultPageSize
+	}
+
+	if 0 >= p.Size {
+		p.Size = DefaultPageSize
+	}
+
+	if 0 >= p.Number {
+		p.Number = 1
+	}
+
+	return p
+}
+
+// Paginator provides an interface for producing the
+// pagination links of a collection.
+type Paginator interface {
+	// Paginate returns the pagination links for the page `p`
+	// of the collection requested with the URL `u`, keyed by
+	// LinkFirst, LinkPrev, LinkNext and LinkLast.
+	Paginate(u *url.URL, p PageResult) map[string]interface{}
+}
+
+// PaginatorFunc is an adapter allowing the use of
+// ordinary functions as implementations of the
+// Paginator interface.
+type PaginatorFunc func(u *url.URL, p PageResult) map[string]interface{}
+
+// Paginate implements the Paginator interface
+// for the PaginatorFunc type.
+func (f PaginatorFunc) Paginate(u *url.URL, p PageResult) map[string]interface{} {
+	return f(u, p)
+}
+
+// OffsetPaginator is a type implementing the Paginator
+// interface, linking pages by the `offset` and `limit`
+// members of the page parameter family.
+type OffsetPaginator struct {
+	// Parameter is the page parameter family,
+	// PageParameter if empty.
+	Parameter string
+}
+
+// Paginate implements the Paginator interface
+// for the OffsetPaginator type.
+func (o OffsetPaginator) Paginate(u *url.URL, p PageResult) map[string]interface{} {
+	p = p.Defaults()
+
+	var (
+		links = make(map[string]interface{})
+		limit = strconv.Itoa(p.Limit)
+	)
+
+	links[LinkFirst] = pageLink(u, o.Parameter, PageOffset, "0", PageLimit, limit)
+
+	if 0 < p.Offset {
+		var prev = p.Offset - p.Limit
+
+		if 0 > prev {
+			prev = 0
+		}
+
+		links[LinkPrev] = pageLink(u, o.Parameter, PageOffset, strconv.Itoa(prev), PageLimit, limit)
+	}
+
+	if !p.HasTotal || p.Offset+p.Limit < p.Total {
+		links[LinkNext] = pageLink(u, o.Parameter, PageOffset, strconv.Itoa(p.Offset+p.Limit), PageLimit, limit)
+	}
+
+	if p.HasTotal && 0 < p.Limit {
+		var last = 0
+
+		if 0 < p.Total {
+			last = (p.Total - 1) / p.Limit * p.Limit
+		}
+
+		links[LinkLast] = pageLink(u, o.Parameter, PageOffset, strconv.Itoa(last), PageLimit, limit)
+	}
+
+	return links
+}
+
+// PageNumberPaginator is a type implementing the Paginator
+// interface, linking pages by the `number` and `size`
+// members of the page parameter family.
+type PageNumberPaginator struct {
+	// Parameter is the page parameter family,
+	// PageParameter if empty.
+	Parameter string
+}
+
+// Paginate implements the Paginator interface
+// for the PageNumberPaginator type.
+func (n PageNumberPaginator) Paginate(u *url.URL, p PageResult) map[string]interface{} {
+	p = p.Defaults()
+
+	var (
+		links = make(map[string]interface{})
+		size  = strconv.Itoa(p.Size)
+	)
+
+	links[LinkFirst] = pageLink(u, n.Parameter, PageNumber, "1", PageSize, size)
+
+	if 1 < p.Number {
+		links[LinkPrev] = pageLink(u, n.Parameter, PageNumber, strconv.Itoa(p.Number-1), PageSize, size)
+	}
+
+	if !p.HasTotal || p.Number*p.Size < p.Total {
+		links[LinkNext] = pageLink(u, n.Parameter, PageNumber, strconv.Itoa(p.Number+1), PageSize, size)
+	}
+
+	if p.HasTotal && 0 < p.Size {
+		var last = 1
+
+		if 0 < p.Total {
+			last = (p.Total + p.Size - 1) / p.Size
+		}
+
+		links[LinkLast] = pageLink(u, n.Parameter, PageNumber, strconv.Itoa(last), PageSize, size)
+	}
+
+	return links
+}
+
+// CursorPaginator is a type implementing the Paginator
+// interface, linking pages by the `cursor` and `size`
+// members of the page parameter family. Collections
+// paginated by cursor have no link to their last page.
+type CursorPaginator struct {
+	// Parameter is the page parameter family,
+	// PageParameter if empty.
+	Parameter string
+}
+
+// Paginate implements the Paginator interface
+// for the CursorPaginator type.
+func (c CursorPaginator) Paginate(u *url.URL, p PageResult) map[string]interface{} {
+	p = p.Defaults()
+
+	var (
+		links = make(map[string]interface{})
+		size  = strconv.Itoa(p.Size)
+	)
+
+	links[LinkFirst] = pageLink(u, c.Parameter, PageCursor, "", PageSize, size)
+
+	if 0 < len(p.PrevCursor) {
+		links[LinkPrev] = pageLink(u, c.Parameter, PageCursor, p.PrevCursor, PageSize, size)
+	}
+
+	if 0 < len(p.NextCursor) {
+		links[LinkNext] = pageLink(u, c.Parameter, PageCursor, p.NextCursor, PageSize, size)
+	}
+
+	return links
+}
+
+// Paginate returns an Option adding the pagination links
+// produced by the Paginator `p` for the page `r` of the
+// collection requested with the URL `u` to the top level
+// links of a document, and the total number of resources,
+// if known, to its top level meta information under
+// MetaTotal.
+func Paginate(p Paginator, u *url.URL, r PageResult) Option {
+	return func(o *Options) {
+		if nil == o.Links {
+			o.Links = make(map[string]interface{})
+		}
+
+		for key, value := range p.Paginate(u, r) {
+			o.Links[key] = value
+		}
+
+		if !r.HasTotal {
+			return
+		} else if nil == o.Meta {
+			o.Meta = make(map[string]interface{})
+		}
+
+		o.Meta[MetaTotal] = r.Total
+	}
+}
+
+// pageLink returns a copy of the URL `u` with the members
+// `k1` and `k2` of the page parameter family `p` set to
+// `v1` and `v2`. Members with an empty value are removed.
+func pageLink(u *url.URL, p, k1, v1, k2, v2 string) string {
+	var (
+		link  = *u
+		query = u.Query()
+	)
+
+	if 0 == len(p) {
+		p = PageParameter
+	}
+
+	for _, member := range [][2]string{{k1, v1}, {k2, v2}} {
+		var key = p + "[" + member[0] + "]"
+
+		if 0 < len(member[1]) {
+			query.Set(key, member[1])
+		} else {
+			query.Del(key)
+		}
+	}
+
+	link.RawQuery = query.Encode()
+
+	return link.String()
+}
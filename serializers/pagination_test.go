@@ -0,0 +1,134 @@
+package serializers_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffsetPaginator(t *testing.T) {
+	var (
+		u, _  = url.Parse("http://example.com/posts?sort=title&page[offset]=10&page[limit]=10")
+		links = serializers.OffsetPaginator{}.Paginate(u, serializers.PageResult{Offset: 10, Limit: 10, Total: 25, HasTotal: true})
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "http://example.com/posts?page%5Blimit%5D=10&page%5Boffset%5D=0&sort=title",
+		"prev":  "http://example.com/posts?page%5Blimit%5D=10&page%5Boffset%5D=0&sort=title",
+		"next":  "http://example.com/posts?page%5Blimit%5D=10&page%5Boffset%5D=20&sort=title",
+		"last":  "http://example.com/posts?page%5Blimit%5D=10&page%5Boffset%5D=20&sort=title",
+	}, links, "failed to produce offset pagination links")
+
+	links = serializers.OffsetPaginator{}.Paginate(u, serializers.PageResult{Offset: 0, Limit: 10})
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "http://example.com/posts?page%5Blimit%5D=10&page%5Boffset%5D=0&sort=title",
+		"next":  "http://example.com/posts?page%5Blimit%5D=10&page%5Boffset%5D=10&sort=title",
+	}, links, "failed to produce offset pagination links without total")
+}
+
+func TestPageNumberPaginator(t *testing.T) {
+	var (
+		u, _  = url.Parse("/posts")
+		links = serializers.PageNumberPaginator{"p"}.Paginate(u, serializers.PageResult{Number: 3, Size: 10, Total: 30, HasTotal: true})
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "/posts?p%5Bnumber%5D=1&p%5Bsize%5D=10",
+		"prev":  "/posts?p%5Bnumber%5D=2&p%5Bsize%5D=10",
+		"last":  "/posts?p%5Bnumber%5D=3&p%5Bsize%5D=10",
+	}, links, "failed to produce page number pagination links")
+
+	links = serializers.PageNumberPaginator{}.Paginate(u, serializers.PageResult{Number: 1, Size: 10}.WithTotal(0))
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "/posts?page%5Bnumber%5D=1&page%5Bsize%5D=10",
+		"last":  "/posts?page%5Bnumber%5D=1&page%5Bsize%5D=10",
+	}, links, "failed to produce page number pagination links for empty collection")
+}
+
+func TestPaginatorDefaults(t *testing.T) {
+	var u, _ = url.Parse("/posts")
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "/posts?page%5Blimit%5D=10&page%5Boffset%5D=0",
+		"next":  "/posts?page%5Blimit%5D=10&page%5Boffset%5D=10",
+		"last":  "/posts?page%5Blimit%5D=10&page%5Boffset%5D=20",
+	}, serializers.OffsetPaginator{}.Paginate(u, serializers.PageResult{Total: 25, HasTotal: true}), "failed to produce offset pagination links for default page")
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "/posts?page%5Bnumber%5D=1&page%5Bsize%5D=10",
+		"next":  "/posts?page%5Bnumber%5D=2&page%5Bsize%5D=10",
+		"last":  "/posts?page%5Bnumber%5D=3&page%5Bsize%5D=10",
+	}, serializers.PageNumberPaginator{}.Paginate(u, serializers.PageResult{Total: 25, HasTotal: true}), "failed to produce page number pagination links for default page")
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "/posts?page%5Bsize%5D=10",
+		"next":  "/posts?page%5Bcursor%5D=b&page%5Bsize%5D=10",
+	}, serializers.CursorPaginator{}.Paginate(u, serializers.PageResult{NextCursor: "b"}), "failed to produce cursor pagination links for default page")
+}
+
+func TestCursorPaginator(t *testing.T) {
+	var (
+		u, _  = url.Parse("/posts?page[cursor]=b&page[size]=2")
+		links = serializers.CursorPaginator{}.Paginate(u, serializers.PageResult{Size: 2, PrevCursor: "a", NextCursor: "c"})
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"first": "/posts?page%5Bsize%5D=2",
+		"prev":  "/posts?page%5Bcursor%5D=a&page%5Bsize%5D=2",
+		"next":  "/posts?page%5Bcursor%5D=c&page%5Bsize%5D=2",
+	}, links, "failed to produce cursor pagination links")
+}
+
+func TestPaginatorFunc(t *testing.T) {
+	var paginator = serializers.PaginatorFunc(func(u *url.URL, p serializers.PageResult) map[string]interface{} {
+		return map[string]interface{}{"next": u.Path}
+	})
+
+	assert.Equal(t, map[string]interface{}{"next": "/posts"}, paginator.Paginate(&url.URL{Path: "/posts"}, serializers.PageResult{}), "failed to call function")
+}
+
+func TestV1AcceptPaginate(t *testing.T) {
+	var (
+		u, _        = url.Parse("/revisions")
+		result, err = NewV1().Accept([]Revision{{ID: 3, Title: "Lorem"}},
+			serializers.Paginate(serializers.PageNumberPaginator{}, u, serializers.PageResult{Number: 2, Size: 1, Total: 3, HasTotal: true}),
+			serializers.Meta(map[string]interface{}{"request": "abc"}),
+		)
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"data": [{"type": "revisions", "id": "3", "attributes": {"title": "Lorem"}, "meta": {"version": 0, "stale": false}}],
+		"links": {
+			"first": "/revisions?page%5Bnumber%5D=1&page%5Bsize%5D=1",
+			"prev": "/revisions?page%5Bnumber%5D=1&page%5Bsize%5D=1",
+			"next": "/revisions?page%5Bnumber%5D=3&page%5Bsize%5D=1",
+			"last": "/revisions?page%5Bnumber%5D=3&page%5Bsize%5D=1"
+		},
+		"meta": {"total": 3, "request": "abc"}
+	}`, string(encoded), "failed to serialize pagination links and meta")
+}
+
+func TestPaginateUnknownTotal(t *testing.T) {
+	var (
+		u, _    = url.Parse("/revisions")
+		options = serializers.NewOptions(serializers.Paginate(serializers.PageNumberPaginator{}, u, serializers.PageResult{}))
+	)
+
+	assert.Nil(t, options.Meta, "added total of PageResult without one")
+	assert.NotContains(t, options.Links, "last", "linked last page of PageResult without total")
+
+	options = serializers.NewOptions(serializers.Paginate(serializers.PageNumberPaginator{}, u, serializers.PageResult{}.WithTotal(0)))
+
+	assert.Equal(t, map[string]interface{}{"total": 0}, options.Meta, "failed to add total of zero")
+}
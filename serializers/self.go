@@ -0,0 +1,94 @@
+package serializers
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TranqSelf represents the struct tag containing the
+	// URL template of a resource's `self` link. It may be
+	// placed on any field of the resource's type, usually
+	// its identifier.
+	TranqSelf = "tranq_self"
+	// IdentifierPlaceholder is replaced with the identifier
+	// of a resource when expanding its self URL template.
+	// Templates without it have the identifier appended
+	// as a final path segment.
+	IdentifierPlaceholder = "{id}"
+)
+
+// SelfLinkConflictError occurs when a resource serialized by Base
+// has both a self link and a relationship named by the JSON API
+// reserved string `self`, both being keyed by it under `links`.
+type SelfLinkConflictError struct {
+	Type reflect.Type
+	Name string
+}
+
+// Error implements the `error` interface for the
+// SelfLinkConflictError type.
+func (s SelfLinkConflictError) Error() string {
+	return fmt.Sprintf("relationship `%s` of type `%s` conflicts with its self link", s.Name, s.Type)
+}
+
+// SelfTemplate returns the URL template of the `self` link for
+// resources of the type `t`, read from SelfTemplates by formatted
+// type name or else from the TranqSelf struct tag, and whether
+// the type has one.
+func (b *Base) SelfTemplate(t reflect.Type) (string, bool, error) {
+	var typ, err = TypeName(t)
+
+	if nil != err {
+		return "", false, err
+	}
+
+	if template, ok := b.SelfTemplates[b.FormatTypeName(typ)]; ok {
+		return template, true, nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if template, ok := t.Field(i).Tag.Lookup(TranqSelf); ok {
+			return template, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// SelfLink returns the URL of the `self` link for the struct
+// value `v` and whether it has one, expanding the URL template
+// of its type with its identifier.
+func (b *Base) SelfLink(v reflect.Value) (string, bool, error) {
+	var metadata = b.Metadata(v.Type())
+
+	if !metadata.HasSelf {
+		return "", false, nil
+	}
+
+	var (
+		template       = metadata.SelfTemplate
+		id, identified = metadata.identifier(v)
+	)
+
+	if !identified {
+		return "", false, nil
+	}
+
+	return ExpandSelfTemplate(template, id), true, nil
+}
+
+// ExpandSelfTemplate expands the self URL template `t` with
+// the identifier `id`, replacing IdentifierPlaceholder or
+// else appending it as a final path segment.
+func ExpandSelfTemplate(t string, id interface{}) string {
+	var segment = url.PathEscape(fmt.Sprint(id))
+
+	if strings.Contains(t, IdentifierPlaceholder) {
+		return strings.Replace(t, IdentifierPlaceholder, segment, -1)
+	}
+
+	return strings.TrimSuffix(t, "/") + "/" + segment
+}
@@ -0,0 +1,98 @@
+package serializers_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Article struct {
+	ID    string `tranq_self:"/api/articles"`
+	Title string
+}
+
+func TestSelfTemplate(t *testing.T) {
+	var serializer = new(serializers.Base)
+
+	var template, ok, err = serializer.SelfTemplate(reflect.TypeOf(Article{}))
+
+	assert.Nil(t, err, "received unexpected error from SelfTemplate")
+	assert.True(t, ok, "failed to read self template from struct tag")
+	assert.Equal(t, "/api/articles", template, "failed to read self template from struct tag")
+
+	serializer.SelfTemplates = map[string]string{"Article": "/v2/articles/{id}"}
+
+	template, ok, err = serializer.SelfTemplate(reflect.TypeOf(Article{}))
+
+	assert.Nil(t, err, "received unexpected error from SelfTemplate")
+	assert.Equal(t, "/v2/articles/{id}", template, "failed to prefer SelfTemplates over struct tag")
+
+	_, ok, err = serializer.SelfTemplate(reflect.TypeOf(Method{}))
+
+	assert.Nil(t, err, "received unexpected error from SelfTemplate")
+	assert.False(t, ok, "reported self template for type without one")
+}
+
+func TestSelfLink(t *testing.T) {
+	var serializer = new(serializers.Base)
+
+	var link, ok, err = serializer.SelfLink(reflect.ValueOf(Article{ID: "a b"}))
+
+	assert.Nil(t, err, "received unexpected error from SelfLink")
+	assert.True(t, ok, "failed to produce self link")
+	assert.Equal(t, "/api/articles/a%20b", link, "failed to append escaped identifier")
+
+	serializer.SelfTemplates = map[string]string{"Article": "/v2/articles/{id}/view"}
+	link, _, _ = serializer.SelfLink(reflect.ValueOf(Article{ID: "1"}))
+
+	assert.Equal(t, "/v2/articles/1/view", link, "failed to replace identifier placeholder")
+}
+
+func TestAcceptSelfLink(t *testing.T) {
+	var serializer = new(serializers.Base)
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Self = "self"
+
+	var result, err = serializer.Accept(Article{"1", "Lorem"})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, map[string]interface{}{
+		"Article": map[string]interface{}{
+			"ID":    "1",
+			"Title": "Lorem",
+			"links": map[string]interface{}{"self": "/api/articles/1"},
+		},
+	}, result, "failed to serialize self link")
+}
+
+func TestAcceptSelfLinkConflict(t *testing.T) {
+	type Review struct {
+		ID   string  `tranq_self:"/api/reviews"`
+		Self Article `tranq_link:"true"`
+	}
+
+	var (
+		serializer = &serializers.Base{AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower)}
+		review     = Review{"1", Article{"2", "Lorem"}}
+		buffer     bytes.Buffer
+	)
+
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Self = "self"
+
+	var _, err = serializer.Accept(review)
+
+	assert.Equal(t, serializers.SelfLinkConflictError{reflect.TypeOf(review), "self"}, err, "failed to return serializers.SelfLinkConflictError")
+
+	serializer.TypeCache = new(serializers.TypeCache)
+	err = serializers.NewEncoder(&buffer, serializer).Encode(review)
+
+	assert.IsType(t, serializers.SelfLinkConflictError{}, err, "failed to return serializers.SelfLinkConflictError from Encode")
+}
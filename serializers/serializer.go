@@ -1,10 +1,19 @@
 package serializers
 
+import (
+	"context"
+)
+
 // Serializer interface provides the ability
 // to serialize go objects into the format
 // needed to comply with standards set by JSON API.
 type Serializer interface {
 	// Accept provides the Serializer with the
-	// go object for serializtion.
-	Accept(i interface{}) (map[string]interface{}, error)
+	// go object for serializtion, along with any
+	// Options for the single call.
+	Accept(i interface{}, options ...Option) (map[string]interface{}, error)
+	// AcceptContext serializes as Accept does, returning
+	// the error of the context.Context `ctx` once it is
+	// done rather than serializing further resources.
+	AcceptContext(ctx context.Context, i interface{}, options ...Option) (map[string]interface{}, error)
 }
@@ -0,0 +1,114 @@
+package serializers
+
+import (
+	"context"
+)
+
+// State contains the state of a single call to a Serializer's
+// `Accept` method, kept apart from the settings of Base so that
+// a configured serializer may be shared between goroutines.
+type State struct {
+	// Path contains the type and identifier pairs of
+	// the resources currently being serialized, from
+	// the root of the document to the current resource.
+	Path [][2]string
+	// IncludePaths contains the relationship paths
+	// sideloaded beneath the resource currently being
+	// serialized, set from the Options provided to
+	// each call to Accept.
+	IncludePaths IncludePaths
+	// Fieldsets contains the sparse fieldsets of the
+	// document currently being serialized, set from
+	// the Options provided to each call to Accept.
+	Fieldsets Fieldsets
+	// StrictFields determines whether sparse fieldsets
+	// naming unknown attributes fail serialization,
+	// set from the Options provided to each call
+	// to Accept.
+	StrictFields bool
+	// RootContext is the base map[string]interface{}
+	// created to contain the serialized JSON API
+	// response.
+	RootContext map[string]interface{}
+	// LinkedDocuments contains the serialized linked
+	// documents of the current document, keyed by
+	// formatted type name and identifier.
+	LinkedDocuments map[[2]string]map[string]interface{}
+	// types caches the reflection metadata of types
+	// during a call to Accept when Base has no
+	// TypeCache.
+	types *TypeCache
+	// nesting is the number of maps currently
+	// being serialized within each other.
+	nesting int
+	// context is the context.Context of the
+	// call to AcceptContext in progress.
+	context context.Context
+}
+
+// Context returns the context.Context of the call
+// to AcceptContext in progress, or the background
+// context outside of one.
+func (s *State) Context() context.Context {
+	if nil == s.context {
+		return context.Background()
+	}
+
+	return s.context
+}
+
+// Err returns the error of the Context once it is done,
+// and nil otherwise. Serializers check it before each
+// resource they serialize.
+func (s *State) Err() error {
+	if nil == s.context {
+		return nil
+	}
+
+	select {
+	case <-s.context.Done():
+		return s.context.Err()
+	default:
+		return nil
+	}
+}
+
+// Fork returns a copy of Base sharing its settings with an empty
+// State, serializing a single document without modifying Base.
+// Accept forks Base on every call, so a Base may serve concurrent
+// calls as long as its settings are not changed.
+func (b *Base) Fork() *Base {
+	var fork = *b
+	fork.State = State{}
+
+	return &fork
+}
+
+// ForkContext returns a Fork of Base whose State
+// carries the context.Context `ctx`.
+func (b *Base) ForkContext(ctx context.Context) *Base {
+	var fork = b.Fork()
+	fork.context = ctx
+
+	return fork
+}
+
+// Fork returns a copy of V1 sharing its settings with
+// an empty State and no sideloaded resources, as
+// Base's Fork does.
+func (v *V1) Fork() *V1 {
+	var fork = *v
+	fork.State = State{}
+	fork.Included = nil
+
+	return &fork
+}
+
+// ForkContext returns a Fork of V1 whose State
+// carries the context.Context `ctx`.
+func (v *V1) ForkContext(ctx context.Context) *V1 {
+	var fork = v.Fork()
+	fork.context = ctx
+
+	return fork
+}
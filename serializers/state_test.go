@@ -0,0 +1,138 @@
+package serializers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFork(t *testing.T) {
+	var serializer = serializers.Base{MaxDepth: 2, TypeCache: new(serializers.TypeCache)}
+
+	serializer.ReservedStrings.ID = "id"
+	serializer.Path = [][2]string{{"Friend", "1"}}
+	serializer.IncludePaths = serializers.ParseIncludePaths("Friend")
+
+	var fork = serializer.Fork()
+
+	assert.Equal(t, serializer.MaxDepth, fork.MaxDepth, "failed to copy settings")
+	assert.Equal(t, serializer.ReservedStrings, fork.ReservedStrings, "failed to copy reserved strings")
+	assert.True(t, serializer.TypeCache == fork.TypeCache, "failed to share TypeCache")
+	assert.Equal(t, serializers.State{}, fork.State, "failed to empty State")
+
+	fork.Path = append(fork.Path, [2]string{"Friend", "2"})
+
+	assert.Len(t, serializer.Path, 1, "modified State of forked serializer")
+}
+
+func TestV1Fork(t *testing.T) {
+	var serializer = NewV1()
+
+	serializer.Included = []interface{}{"resource"}
+	serializer.Fieldsets = serializers.Fieldsets{"posts": {"title": struct{}{}}}
+
+	var fork = serializer.Fork()
+
+	assert.Equal(t, serializer.ReservedStrings, fork.ReservedStrings, "failed to copy reserved strings")
+	assert.Equal(t, serializers.State{}, fork.State, "failed to empty State")
+	assert.Nil(t, fork.Included, "failed to empty Included")
+	assert.Len(t, serializer.Included, 1, "modified forked serializer")
+}
+
+// assertConcurrent serializes `i` with `s` from many goroutines at
+// once, asserting every document matches the one serialized first.
+func assertConcurrent(t *testing.T, s serializers.Serializer, i interface{}, options ...serializers.Option) {
+	var (
+		expected, err = s.Accept(i, options...)
+		encoded, _    = json.Marshal(expected)
+		group         sync.WaitGroup
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	for j := 0; j < 8; j++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			for k := 0; k < 16; k++ {
+				var (
+					result, err = s.Accept(i, options...)
+					actual, _   = json.Marshal(result)
+					buffer      bytes.Buffer
+				)
+
+				assert.Nil(t, err, "received unexpected error from concurrent Accept")
+				assert.Equal(t, string(encoded), string(actual), "failed to serialize document concurrently")
+
+				err = serializers.NewEncoder(&buffer, s).Encode(i, options...)
+
+				assert.Nil(t, err, "received unexpected error from concurrent Encode")
+				assert.Equal(t, string(encoded), buffer.String(), "failed to encode document concurrently")
+			}
+		}()
+	}
+
+	group.Wait()
+}
+
+func TestAcceptConcurrent(t *testing.T) {
+	var (
+		cached     = serializers.Base{TypeCache: new(serializers.TypeCache), LinkTemplates: true}
+		uncached   = serializers.Base{ConflictPolicy: serializers.ConflictMerge}
+		jon        = &Friend{ID: 1, Name: "Jon"}
+		jane       = &Friend{ID: 2, Name: "Jane", Friend: jon}
+		serializer *serializers.Base
+	)
+
+	jon.Friend = jane
+
+	for _, serializer = range []*serializers.Base{&cached, &uncached} {
+		serializer.ReservedStrings.ID = "id"
+		serializer.ReservedStrings.IDs = "ids"
+		serializer.ReservedStrings.Links = "links"
+		serializer.ReservedStrings.Linked = "linked"
+		serializer.ReservedStrings.Type = "type"
+		serializer.ReservedStrings.Href = "href"
+
+		assertConcurrent(t, serializer, jon)
+		assertConcurrent(t, serializer, entries(), serializers.Include("Readers"), serializers.Fields("Entry", "Title", "Readers"))
+	}
+}
+
+func TestV1AcceptConcurrent(t *testing.T) {
+	var serializer = NewV1()
+
+	assertConcurrent(t, serializer, entries())
+	assertConcurrent(t, serializer, entries(), serializers.Include("author"), serializers.Fields("entrys", "title", "author"))
+
+	serializer.TypeCache = new(serializers.TypeCache)
+
+	assertConcurrent(t, serializer, entries())
+}
+
+func TestStateContext(t *testing.T) {
+	var (
+		state       serializers.State
+		ctx, cancel = context.WithCancel(context.Background())
+		fork        = new(serializers.Base).ForkContext(ctx)
+	)
+
+	assert.Equal(t, context.Background(), state.Context(), "failed to default to background context")
+	assert.Nil(t, state.Err(), "returned error without context")
+	assert.Equal(t, ctx, fork.Context(), "failed to carry context")
+	assert.Nil(t, fork.Err(), "returned error before context was done")
+
+	cancel()
+
+	assert.Equal(t, context.Canceled, fork.Err(), "failed to return error of done context")
+	assert.Equal(t, ctx, NewV1().ForkContext(ctx).Context(), "failed to carry context")
+}
@@ -0,0 +1,118 @@
+package serializers
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	// Tranq represents the struct tag containing a
+	// field's attribute name followed by comma
+	// separated options, i.e. `tranq:"name,omitempty"`.
+	Tranq = "tranq"
+	// JSON represents the struct tag used by the
+	// `encoding/json` package, optionally read in
+	// place of a missing `tranq` struct tag.
+	JSON = "json"
+	// Ignore represents the `tranq` struct tag value
+	// marking a field to be skipped entirely.
+	Ignore = "-"
+	// OmitEmpty represents the `tranq` struct tag option
+	// marking a field to be skipped when it is set to
+	// its zero value.
+	OmitEmpty = "omitempty"
+)
+
+// Tag represents the serialization options for a
+// struct field, parsed from its struct tags.
+type Tag struct {
+	// Name overrides the formatted attribute name
+	// of the field when not empty.
+	Name string
+	// Ignore determines whether the field is
+	// skipped entirely.
+	Ignore bool
+	// OmitEmpty determines whether the field is
+	// skipped when set to its zero value.
+	OmitEmpty bool
+	// JSON determines whether the Tag was parsed from
+	// the `json` struct tag, in which case OmitEmpty
+	// skips the values IsEmptyJSONValue reports.
+	JSON bool
+}
+
+// ParseTag parses the `tranq` and `tranq_ignore` struct tags
+// of the reflect.StructField `f`. Unrecognized options are
+// ignored.
+func ParseTag(f reflect.StructField) Tag {
+	return parseTag(f, Tranq)
+}
+
+// ParseJSONTag parses the `json` and `tranq_ignore` struct
+// tags of the reflect.StructField `f`, following the same
+// grammar as ParseTag. Fields with a `json` struct tag
+// are omitted when empty as encoding/json omits them.
+func ParseJSONTag(f reflect.StructField) Tag {
+	return parseTag(f, JSON)
+}
+
+// parseTag parses the struct tag `key` of the
+// reflect.StructField `f`.
+func parseTag(f reflect.StructField, key string) Tag {
+	var (
+		tag       = Tag{Ignore: "true" == f.Tag.Get(TranqIgnore)}
+		value, ok = f.Tag.Lookup(key)
+	)
+
+	if !ok {
+		return tag
+	}
+
+	tag.JSON = JSON == key
+
+	if Ignore == value {
+		tag.Ignore = true
+		return tag
+	}
+
+	var options = strings.Split(value, ",")
+
+	tag.Name = options[0]
+
+	for i := 1; i < len(options); i++ {
+		switch options[i] {
+		case OmitEmpty:
+			tag.OmitEmpty = true
+		}
+	}
+
+	return tag
+}
+
+// IsEmptyJSONValue reports whether the value `i` is empty as
+// defined by encoding/json for the `omitempty` option: false,
+// 0, nil pointers and interfaces, and arrays, slices, maps and
+// strings of length 0. Unlike IsZeroValue, structs, such as
+// time.Time, and arrays of zero values are never empty.
+func IsEmptyJSONValue(i interface{}) bool {
+	var v = reflect.ValueOf(i)
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return 0 == v.Len()
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return 0 == v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return 0 == v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return 0 == v.Float()
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+
+	return false
+}
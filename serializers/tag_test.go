@@ -0,0 +1,115 @@
+package serializers_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	type TStruct struct {
+		None      string
+		Renamed   string `tranq:"renamed"`
+		Omitted   string `tranq:",omitempty"`
+		Both      string `tranq:"both,omitempty,unknown"`
+		Ignored   string `tranq:"-"`
+		Legacy    string `tranq_ignore:"true"`
+		NotLegacy string `tranq_ignore:"false"`
+	}
+
+	var (
+		typ      = reflect.TypeOf(TStruct{})
+		expected = []serializers.Tag{
+			serializers.Tag{},
+			serializers.Tag{Name: "renamed"},
+			serializers.Tag{OmitEmpty: true},
+			serializers.Tag{Name: "both", OmitEmpty: true},
+			serializers.Tag{Ignore: true},
+			serializers.Tag{Ignore: true},
+			serializers.Tag{},
+		}
+	)
+
+	for i := 0; i < typ.NumField(); i++ {
+		assert.Equal(t, expected[i], serializers.ParseTag(typ.Field(i)), "failed to parse struct tags of field `%s`", typ.Field(i).Name)
+	}
+}
+
+func TestParseJSONTag(t *testing.T) {
+	type TStruct struct {
+		Renamed string `json:"first_name,omitempty"`
+		Ignored string `json:"-"`
+		Dash    string `json:"-,"`
+		Tranq   string `tranq:"tranq"`
+	}
+
+	var (
+		typ      = reflect.TypeOf(TStruct{})
+		expected = []serializers.Tag{
+			serializers.Tag{Name: "first_name", OmitEmpty: true, JSON: true},
+			serializers.Tag{Ignore: true, JSON: true},
+			serializers.Tag{Name: "-", JSON: true},
+			serializers.Tag{},
+		}
+	)
+
+	for i := 0; i < typ.NumField(); i++ {
+		assert.Equal(t, expected[i], serializers.ParseJSONTag(typ.Field(i)), "failed to parse struct tags of field `%s`", typ.Field(i).Name)
+	}
+}
+
+func TestIsEmptyJSONValue(t *testing.T) {
+	var (
+		empty  = []interface{}{nil, false, 0, uint8(0), 0.0, "", (*int)(nil), []int{}, map[string]int{}, [0]int{}}
+		values = []interface{}{true, 1, -0.5, "a", new(int), []int{0}, [2]int{}, time.Time{}, struct{}{}}
+	)
+
+	for _, value := range empty {
+		assert.True(t, serializers.IsEmptyJSONValue(value), "failed to report %#v as empty", value)
+	}
+
+	for _, value := range values {
+		assert.False(t, serializers.IsEmptyJSONValue(value), "reported %#v as empty", value)
+	}
+}
+
+func TestJSONTagOmitEmpty(t *testing.T) {
+	type TStruct struct {
+		ID      int               `json:"id"`
+		Created time.Time         `json:"created,omitempty"`
+		Labels  map[string]string `json:"labels,omitempty"`
+		Ratio   float64           `json:"ratio,omitempty"`
+		Parent  *TStruct          `json:"parent,omitempty"`
+		Title   string            `json:"title,omitempty"`
+		Stamp   time.Time         `tranq:"stamp,omitempty"`
+	}
+
+	var (
+		serializer = serializers.Base{UseJSONTags: true}
+		value      = TStruct{ID: 1}
+		marshaled  = make(map[string]interface{})
+		data, _    = json.Marshal(value)
+	)
+
+	var result, err = serializer.Accept(value)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Nil(t, json.Unmarshal(data, &marshaled))
+
+	var attributes, _ = result["TStruct"].(map[string]interface{})
+
+	delete(marshaled, "Stamp")
+
+	for key := range marshaled {
+		assert.Contains(t, attributes, key, "omitted attribute encoding/json keeps")
+	}
+
+	assert.Equal(t, len(marshaled), len(attributes), "kept attribute encoding/json omits")
+	assert.NotContains(t, attributes, "stamp", "failed to omit zero value of field with tranq struct tag")
+}
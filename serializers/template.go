@@ -0,0 +1,266 @@
+package serializers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// TemplateOwner is the URI template variable containing
+	// the formatted type name of the resource owning a link.
+	TemplateOwner = "owner"
+	// TemplateOwnerID is the URI template variable containing
+	// the identifier of the resource owning a link.
+	TemplateOwnerID = "owner.id"
+	// TemplateChild is the URI template variable containing
+	// the formatted type name of the linked resources.
+	TemplateChild = "child"
+	// TemplateIDs is the URI template variable containing
+	// the list of identifiers of the linked resources.
+	TemplateIDs = "ids"
+)
+
+const (
+	// reserved contains the characters of the reserved
+	// set of RFC 3986, left unencoded by the `+` and `#`
+	// operators of RFC 6570.
+	reserved = ":/?#[]@!$&'()*+,;="
+	// operators contains the operators of RFC 6570
+	// expressions.
+	operators = "+#./;?&"
+)
+
+// OwnerHrefFormatter provides an interface for HrefFormatters
+// also formatting with the identifier of the resource owning a
+// link. Serializers prefer it to the HrefFormatter interface
+// when an HrefFormatter implements both.
+type OwnerHrefFormatter interface {
+	// FormatOwnerHref formats the href `href` of resources of
+	// type `child` with the identifiers `ids`, linked from the
+	// resource of type `owner` with the identifier `id`.
+	FormatOwnerHref(href, owner string, id interface{}, child string, ids []interface{}) string
+}
+
+// ContextHrefFormatter provides an interface for HrefFormatters
+// formatting with the context.Context of the call to AcceptContext
+// in progress, i.e. to read the host of the request being served.
+// Serializers prefer it to the OwnerHrefFormatter and
+// HrefFormatter interfaces when an HrefFormatter implements it.
+type ContextHrefFormatter interface {
+	// FormatHrefContext formats the href `href` as FormatOwnerHref
+	// does, passing a nil `id` where the identifier of the resource
+	// owning the link is unknown.
+	FormatHrefContext(ctx context.Context, href, owner string, id interface{}, child string, ids []interface{}) string
+}
+
+// TemplateHrefFormatter is a type implementing the HrefFormatter
+// and OwnerHrefFormatter interfaces, interpreting hrefs as RFC 6570
+// URI templates expanded with the variables TemplateOwner,
+// TemplateOwnerID, TemplateChild and TemplateIDs, i.e.
+// `/api/v1/{owner}/{owner.id}/{child}` or `/people/{ids}`.
+type TemplateHrefFormatter struct{}
+
+// FormatHref implements the HrefFormatter interface for the
+// TemplateHrefFormatter type, leaving TemplateOwnerID undefined.
+func (t TemplateHrefFormatter) FormatHref(h, o, c string, i []interface{}) string {
+	return t.FormatOwnerHref(h, o, nil, c, i)
+}
+
+// FormatOwnerHref implements the OwnerHrefFormatter interface
+// for the TemplateHrefFormatter type.
+func (t TemplateHrefFormatter) FormatOwnerHref(h, o string, id interface{}, c string, i []interface{}) string {
+	var (
+		variables = map[string]interface{}{TemplateOwner: o, TemplateChild: c}
+		ids       = make([]string, 0, len(i))
+	)
+
+	for j := 0; j < len(i); j++ {
+		ids = append(ids, fmt.Sprint(i[j]))
+	}
+
+	variables[TemplateIDs] = ids
+
+	if nil != id {
+		variables[TemplateOwnerID] = fmt.Sprint(id)
+	}
+
+	return ExpandTemplate(h, variables)
+}
+
+// LinkTemplate adds the URL template of the relationship `a`
+// from resources of type `o` to resources of type `c` under the
+// top level JSON API reserved string `links`, keyed `o.a`. The
+// href `h` is formatted without identifiers, with the variable
+// `{o.a}` appended for the identifiers of the linked resources.
+func (b *Base) LinkTemplate(o, a, c, h string) {
+	var (
+		key       = o + PathSeparator + a
+		links, ok = b.RootContext[b.ReservedStrings.Links].(map[string]interface{})
+	)
+
+	if !ok {
+		links = make(map[string]interface{})
+		b.RootContext[b.ReservedStrings.Links] = links
+	}
+
+	links[key] = map[string]interface{}{
+		b.ReservedStrings.Href: strings.TrimSuffix(b.FormatHref(h, o, c, []interface{}{}), "/") + "/{" + key + "}",
+		b.ReservedStrings.Type: c,
+	}
+}
+
+// ExpandTemplate expands the RFC 6570 URI template `t` with the
+// variables `v`, each a string or a []string. Variables missing
+// from `v` and empty lists are undefined, expanding to nothing.
+// Associative array values are not supported.
+func ExpandTemplate(t string, v map[string]interface{}) string {
+	var expanded = make([]string, 0, 0)
+
+	for {
+		var start = strings.IndexByte(t, '{')
+
+		if 0 > start {
+			break
+		}
+
+		var end = strings.IndexByte(t[start:], '}')
+
+		if 0 > end {
+			break
+		}
+
+		expanded = append(expanded, t[:start], expandExpression(t[start+1:start+end], v))
+		t = t[start+end+1:]
+	}
+
+	return strings.Join(append(expanded, t), "")
+}
+
+// expandExpression expands the body `e` of a single
+// URI template expression with the variables `v`.
+func expandExpression(e string, v map[string]interface{}) string {
+	var (
+		operator   byte
+		first, sep = "", ","
+		named      bool
+		ifEmpty    string
+		allowed    bool
+	)
+
+	if 0 < len(e) && 0 <= strings.IndexByte(operators, e[0]) {
+		operator, e = e[0], e[1:]
+	}
+
+	switch operator {
+	case '+':
+		allowed = true
+	case '#':
+		first, allowed = "#", true
+	case '.':
+		first, sep = ".", "."
+	case '/':
+		first, sep = "/", "/"
+	case ';':
+		first, sep, named = ";", ";", true
+	case '?':
+		first, sep, named, ifEmpty = "?", "&", true, "="
+	case '&':
+		first, sep, named, ifEmpty = "&", "&", true, "="
+	}
+
+	var parts = make([]string, 0, 0)
+
+	for _, spec := range strings.Split(e, ",") {
+		var (
+			name    = spec
+			explode = strings.HasSuffix(spec, "*")
+			prefix  = -1
+		)
+
+		if explode {
+			name = strings.TrimSuffix(spec, "*")
+		} else if colon := strings.IndexByte(spec, ':'); 0 <= colon {
+			if n, err := strconv.Atoi(spec[colon+1:]); nil == err {
+				name, prefix = spec[:colon], n
+			}
+		}
+
+		switch value := v[name].(type) {
+		case string:
+			if 0 <= prefix && prefix < utf8.RuneCountInString(value) {
+				value = string([]rune(value)[:prefix])
+			}
+
+			if !named {
+				parts = append(parts, encodeTemplateValue(value, allowed))
+			} else if 0 == len(value) {
+				parts = append(parts, name+ifEmpty)
+			} else {
+				parts = append(parts, name+"="+encodeTemplateValue(value, allowed))
+			}
+		case []string:
+			if 0 == len(value) {
+				continue
+			}
+
+			var items = make([]string, 0, len(value))
+
+			for j := 0; j < len(value); j++ {
+				var item = encodeTemplateValue(value[j], allowed)
+
+				if named && explode {
+					item = name + "=" + item
+				}
+
+				items = append(items, item)
+			}
+
+			if explode {
+				parts = append(parts, strings.Join(items, sep))
+			} else if named {
+				parts = append(parts, name+"="+strings.Join(items, ","))
+			} else {
+				parts = append(parts, strings.Join(items, ","))
+			}
+		}
+	}
+
+	if 0 == len(parts) {
+		return ""
+	}
+
+	return first + strings.Join(parts, sep)
+}
+
+// encodeTemplateValue percent-encodes the characters of `s`
+// outside the unreserved set of RFC 3986, leaving reserved
+// characters and percent-encoded triplets if `r` is true.
+func encodeTemplateValue(s string, r bool) string {
+	var encoded strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		var c = s[i]
+
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', 0 <= strings.IndexByte("-._~", c):
+			encoded.WriteByte(c)
+		case r && 0 <= strings.IndexByte(reserved, c):
+			encoded.WriteByte(c)
+		case r && '%' == c && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			encoded.WriteString(s[i : i+3])
+			i += 2
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+
+	return encoded.String()
+}
+
+// isHex returns true if `c` is a hexadecimal digit.
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
@@ -0,0 +1,97 @@
+package serializers_test
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	var (
+		variables = map[string]interface{}{
+			"var":   "value",
+			"hello": "Hello World!",
+			"path":  "/foo/bar",
+			"list":  []string{"red", "green", "blue"},
+			"x":     "1024",
+			"y":     "768",
+			"empty": "",
+		}
+		expansions = map[string]string{
+			"{var}":           "value",
+			"{hello}":         "Hello%20World%21",
+			"{+hello}":        "Hello%20World!",
+			"{+path}/here":    "/foo/bar/here",
+			"{#hello}":        "#Hello%20World!",
+			"X{.var}":         "X.value",
+			"{/var,x}/here":   "/value/1024/here",
+			"{;x,y,empty}":    ";x=1024;y=768;empty",
+			"{?x,y,empty}":    "?x=1024&y=768&empty=",
+			"?fixed=yes{&x}":  "?fixed=yes&x=1024",
+			"{var:3}":         "val",
+			"{+path:6}/here":  "/foo/b/here",
+			"{list}":          "red,green,blue",
+			"{list*}":         "red,green,blue",
+			"{/list*}":        "/red/green/blue",
+			"{?list}":         "?list=red,green,blue",
+			"{?list*}":        "?list=red&list=green&list=blue",
+			"{undef}/{?none}": "/",
+			"/unclosed{var":   "/unclosed{var",
+		}
+	)
+
+	for template, expected := range expansions {
+		assert.Equal(t, expected, serializers.ExpandTemplate(template, variables), "failed to expand template `%s`", template)
+	}
+}
+
+func TestTemplateHrefFormatter(t *testing.T) {
+	var formatter = serializers.TemplateHrefFormatter{}
+
+	assert.Equal(t, "/api/v1/posts/1/people", formatter.FormatOwnerHref("/api/v1/{owner}/{owner.id}/{child}", "posts", 1, "people", []interface{}{2}), "failed to expand owner variables")
+	assert.Equal(t, "/people/1,2", formatter.FormatHref("/people/{ids}", "posts", "people", []interface{}{1, 2}), "failed to expand ids")
+	assert.Equal(t, "/people", formatter.FormatHref("/people{?id*}", "posts", "people", []interface{}{1, 2}), "failed to leave unknown variables undefined")
+	assert.Equal(t, "/people/1/2", formatter.FormatHref("/people{/ids*}", "posts", "people", []interface{}{1, 2}), "failed to explode ids")
+}
+
+func TestAcceptLinkTemplates(t *testing.T) {
+	type Writer struct {
+		ID int
+	}
+
+	type Story struct {
+		ID     int
+		Author Writer `tranq_link:"true" tranq_href:"/writers/{ids}"`
+	}
+
+	var serializer = &serializers.Base{
+		HrefFormatter: serializers.TemplateHrefFormatter{},
+		LinkTemplates: true,
+	}
+
+	serializer.ReservedStrings.ID = "id"
+	serializer.ReservedStrings.Href = "href"
+	serializer.ReservedStrings.Type = "type"
+	serializer.ReservedStrings.Links = "links"
+	serializer.ReservedStrings.Linked = "linked"
+
+	var result, err = serializer.Accept(Story{1, Writer{2}})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"Story": {
+			"id": 1,
+			"links": {"Author": {"id": 2, "href": "/writers/2", "type": "Writer"}}
+		},
+		"links": {
+			"Story.Author": {"href": "/writers/{Story.Author}", "type": "Writer"}
+		}
+	}`, string(encoded), "failed to serialize link templates")
+}
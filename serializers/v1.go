@@ -0,0 +1,958 @@
+package serializers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+// V1 is a type implementing the Serializer interface,
+// producing documents in the format specified by
+// JSON API 1.0. Resources are represented by objects
+// containing `type`, `id`, `attributes` and `relationships`
+// members under the top level `data` member, with linked
+// resources sideloaded into the top level `included` array.
+type V1 struct {
+	Base
+	// Included contains the serialized resources
+	// sideloaded into the document under the JSON
+	// API reserved string `included`.
+	Included []interface{}
+}
+
+// Accept implements the `Accept` method required
+// by the Serializer interface, serializing `i`
+// with a Fork of V1.
+func (v *V1) Accept(i interface{}, options ...Option) (map[string]interface{}, error) {
+	return v.AcceptContext(context.Background(), i, options...)
+}
+
+// AcceptContext implements the `AcceptContext` method
+// required by the Serializer interface, serializing `i`
+// with a Fork of V1 carrying `ctx`.
+func (v *V1) AcceptContext(ctx context.Context, i interface{}, options ...Option) (map[string]interface{}, error) {
+	return v.ForkContext(ctx).accept(i, options)
+}
+
+// accept serializes `i` as Accept
+// does, modifying V1's State.
+func (v *V1) accept(i interface{}, options []Option) (mapping map[string]interface{}, err error) {
+	defer func() {
+		if temp := recover(); nil != temp {
+			if _, ok := temp.(error); ok {
+				err = temp.(error)
+			} else {
+				err = fmt.Errorf("%s", temp)
+			}
+
+			mapping = nil
+		}
+	}()
+
+	if err = v.Err(); nil != err {
+		return nil, err
+	}
+
+	mapping = make(map[string]interface{})
+
+	var o = v.reset(mapping, options)
+
+	if mapping[v.ReservedStrings.Data], err = v.SerializeData(i); nil != err {
+		return nil, err
+	}
+
+	v.addMembers(mapping, o)
+
+	return mapping, nil
+}
+
+// Stream implements the StreamingSerializer interface for
+// the V1 type, writing the members of each primary resource
+// directly from its struct fields rather than building its
+// resource object first. Sideloaded resources are built and
+// buffered until the primary data has been written. If the
+// formatted reserved strings would not place the primary data
+// first in the document, the document is built by Accept. As
+// with Accept, `i` is serialized with a Fork of V1.
+func (v *V1) Stream(e *Encoder, i interface{}, options ...Option) error {
+	return v.StreamContext(context.Background(), e, i, options...)
+}
+
+// StreamContext implements the StreamingSerializer interface
+// for the V1 type, writing the document for `i` as Stream
+// does with a Fork of V1 carrying `ctx`.
+func (v *V1) StreamContext(ctx context.Context, e *Encoder, i interface{}, options ...Option) error {
+	return v.ForkContext(ctx).stream(e, i, options)
+}
+
+// stream writes the document for `i` as
+// Stream does, modifying V1's State.
+func (v *V1) stream(e *Encoder, i interface{}, options []Option) (err error) {
+	defer func() {
+		if temp := recover(); nil != temp {
+			if _, ok := temp.(error); ok {
+				err = temp.(error)
+			} else {
+				err = fmt.Errorf("%s", temp)
+			}
+		}
+	}()
+
+	for _, key := range []string{v.ReservedStrings.Included, v.ReservedStrings.Links, v.ReservedStrings.Meta} {
+		if key <= v.ReservedStrings.Data {
+			var mapping map[string]interface{}
+
+			if mapping, err = v.accept(i, options); nil != err {
+				return err
+			}
+
+			return e.WriteValue(mapping)
+		}
+	}
+
+	if err = v.Err(); nil != err {
+		return err
+	}
+
+	var o = v.reset(make(map[string]interface{}), options)
+
+	if err = e.WriteRaw("{"); nil != err {
+		return err
+	} else if err = e.WriteKey(v.ReservedStrings.Data); nil != err {
+		return err
+	}
+
+	var value, typ, kind, derr = Dereference(i)
+
+	if nil != derr {
+		return derr
+	} else if kind == reflect.Slice || kind == reflect.Array {
+		if err = e.WriteRaw("["); nil != err {
+			return err
+		}
+
+		var written = 0
+
+		err = v.walkCollection(value, func(r reflect.Value) error {
+			if 0 < written {
+				if err := e.WriteRaw(","); nil != err {
+					return err
+				}
+			}
+
+			written++
+
+			return v.streamResource(e, r)
+		})
+
+		if nil != err {
+			return err
+		} else if err = e.WriteRaw("]"); nil != err {
+			return err
+		}
+	} else if kind == reflect.Struct && !IsMarshaler(typ) {
+		if err = v.MarkDocument(value); nil != err {
+			return err
+		} else if err = v.streamResource(e, value); nil != err {
+			return err
+		}
+	} else {
+		var data interface{}
+
+		if data, err = v.SerializeData(i); nil != err {
+			return err
+		} else if err = e.WriteValue(data); nil != err {
+			return err
+		}
+	}
+
+	var (
+		members = make(map[string]interface{})
+		keys    = make([]string, 0, 0)
+	)
+
+	v.addMembers(members, o)
+
+	for key := range members {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for j := 0; j < len(keys); j++ {
+		if err = e.WriteRaw(","); nil != err {
+			return err
+		} else if err = e.WriteKey(keys[j]); nil != err {
+			return err
+		} else if err = e.WriteValue(members[keys[j]]); nil != err {
+			return err
+		}
+	}
+
+	return e.WriteRaw("}")
+}
+
+// reset prepares V1's State for serializing a new document
+// into `mapping` with the Options produced by `options`,
+// returning the Options.
+func (v *V1) reset(mapping map[string]interface{}, options []Option) Options {
+	var o = v.Base.reset(mapping, options)
+
+	v.Included = make([]interface{}, 0, 0)
+
+	return o
+}
+
+// addMembers adds the sideloaded resources and the top level
+// links and meta information of the Options `o` to `mapping`.
+func (v *V1) addMembers(mapping map[string]interface{}, o Options) {
+	if 0 < len(v.Included) {
+		mapping[v.ReservedStrings.Included] = v.Included
+	}
+
+	if 0 < len(o.Links) {
+		mapping[v.ReservedStrings.Links] = o.Links
+	}
+
+	if 0 < len(o.Meta) {
+		mapping[v.ReservedStrings.Meta] = o.Meta
+	}
+}
+
+// SerializeData serializes the primary data of a document,
+// either a single resource, a collection of resources or
+// nil. Primary resources are never sideloaded under the
+// JSON API reserved string `included`.
+func (v *V1) SerializeData(i interface{}) (interface{}, error) {
+	var value, typ, kind, err = Dereference(i)
+
+	if nil != err {
+		return nil, err
+	}
+
+	switch {
+	case kind == reflect.Invalid || kind == reflect.Ptr:
+		return nil, nil
+	case kind == reflect.Struct && !IsMarshaler(typ):
+		if err = v.MarkDocument(value); nil != err {
+			return nil, err
+		}
+
+		return v.SerializeResource(value)
+	case kind != reflect.Slice && kind != reflect.Array:
+		return nil, UnsupportedKindError{kind, v}
+	}
+
+	var collection = make([]interface{}, 0, value.Len())
+
+	err = v.SerializeCollection(value, func(r map[string]interface{}) error {
+		collection = append(collection, r)
+		return nil
+	})
+
+	if nil != err {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// SerializeCollection serializes the resources of the slice or
+// array value `c` in order, passing each to the function `f`.
+// Every resource is marked as present in the document before
+// the first is serialized, and nil pointers are skipped.
+func (v *V1) SerializeCollection(c reflect.Value, f func(r map[string]interface{}) error) error {
+	return v.walkCollection(c, func(r reflect.Value) error {
+		var resource, err = v.SerializeResource(r)
+
+		if nil != err {
+			return err
+		}
+
+		return f(resource)
+	})
+}
+
+// walkCollection passes the struct values of the slice or array
+// value `c` to the function `f` in order, having marked every
+// one as present in the document, skipping nil pointers.
+func (v *V1) walkCollection(c reflect.Value, f func(r reflect.Value) error) error {
+	for j := 0; j < c.Len(); j++ {
+		var temp = c.Index(j)
+
+		if !temp.CanInterface() {
+			return UninterfaceableValueError{temp}
+		}
+
+		var element, etyp, ekind, err = Dereference(temp.Interface())
+
+		if nil != err {
+			return err
+		} else if ekind == reflect.Ptr {
+			continue
+		} else if ekind != reflect.Struct || IsMarshaler(etyp) {
+			return UnsupportedKindError{ekind, v}
+		}
+
+		if err = v.MarkDocument(element); nil != err {
+			return err
+		}
+	}
+
+	for j := 0; j < c.Len(); j++ {
+		var element, _, kind, _ = Dereference(c.Index(j).Interface())
+
+		if kind == reflect.Ptr {
+			continue
+		} else if err := f(element); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SerializeResource attempts to serialize a reflect.Value with a
+// reflect.Kind of reflect.Struct into a JSON API resource object,
+// unless the Context of V1's State is done.
+func (v *V1) SerializeResource(r reflect.Value) (map[string]interface{}, error) {
+	if err := v.Err(); nil != err {
+		return nil, err
+	}
+
+	var (
+		resource      = make(map[string]interface{})
+		attributes    = make(map[string]interface{})
+		relationships = make(map[string]interface{})
+		t             = r.Type()
+		metadata      = v.Metadata(t)
+		id, ok        = metadata.identifier(r)
+		err           error
+	)
+
+	if !ok {
+		return nil, MissingIdentifierError{r, v.IdentifierStrategy}
+	}
+
+	if err = v.EnterDocument(r); nil != err {
+		return nil, err
+	}
+
+	defer v.LeaveDocument()
+
+	resource[v.ReservedStrings.Type] = metadata.Name
+	resource[v.ReservedStrings.ID] = fmt.Sprint(id)
+
+	var (
+		fieldset map[string]struct{}
+		sparse   bool
+	)
+
+	if fieldset, sparse, err = v.Fieldset(t); nil != err {
+		return nil, err
+	}
+
+	for j := 0; j < len(metadata.Fields); j++ {
+		var (
+			field                      = &metadata.Fields[j]
+			val, ftyp, kind, role, err = v.classifyField(r, field, fieldset, sparse)
+		)
+
+		if nil != err {
+			return nil, err
+		} else if fieldOmitted == role {
+			continue
+		} else if fieldLinked == role {
+			if err = v.RelateStructField(relationships, r, val, ftyp, kind, field.Field); nil != err {
+				return nil, err
+			}
+		} else if attributes[field.Name], err = v.attributeValue(val, role); nil != err {
+			return nil, err
+		}
+	}
+
+	if 0 < len(attributes) {
+		resource[v.ReservedStrings.Attributes] = attributes
+	}
+
+	if 0 < len(relationships) {
+		resource[v.ReservedStrings.Relationships] = relationships
+	}
+
+	var self string
+
+	if self, ok, err = v.SelfLink(r); nil != err {
+		return nil, err
+	} else if ok {
+		resource[v.ReservedStrings.Links] = map[string]interface{}{
+			v.ReservedStrings.Self: self,
+		}
+	}
+
+	var meta map[string]interface{}
+
+	if meta, err = v.ResourceMeta(r); nil != err {
+		return nil, err
+	} else if 0 < len(meta) {
+		resource[v.ReservedStrings.Meta] = meta
+	}
+
+	return resource, nil
+}
+
+// RelateStructField attempts to add a JSON API relationship object
+// for a value to a map[string]interface{} of relationships. Nil
+// pointers are related with nil resource linkage. Relationships
+// of resources with a self link are given `self` and `related`
+// links beneath it, the latter replaced by the formatted
+// `tranq_href` struct tag if present. If
+// RelateStructField fails an error detailing what went wrong
+// is returned.
+func (v *V1) RelateStructField(m map[string]interface{}, p, r reflect.Value, t reflect.Type, k reflect.Kind, f reflect.StructField) error {
+	var (
+		field        = v.StructFieldMetadata(p.Type(), f)
+		attr         = field.Name
+		relationship = make(map[string]interface{})
+		href         = field.Href
+		typ          = v.Metadata(t).Name
+		ids          = make([]interface{}, 0, 0)
+		err          error
+	)
+
+	m[attr] = relationship
+
+	var (
+		include, included = v.IncludePaths.Relationship(attr)
+		parent            = v.IncludePaths
+	)
+
+	v.IncludePaths = include
+	defer func() { v.IncludePaths = parent }()
+
+	if k == reflect.Ptr {
+		relationship[v.ReservedStrings.Data] = nil
+	} else if k == reflect.Struct {
+		var identifier map[string]interface{}
+
+		if identifier, err = v.IdentifyResource(r, typ, included); nil != err {
+			return err
+		}
+
+		var id, _ = v.Identifier(r)
+
+		ids = append(ids, id)
+		relationship[v.ReservedStrings.Data] = identifier
+	} else if k == reflect.Slice || k == reflect.Array {
+		var identifiers = make([]interface{}, 0, r.Len())
+
+		for i := 0; i < r.Len(); i++ {
+			var temp = r.Index(i)
+
+			if !temp.CanInterface() {
+				return UninterfaceableValueError{temp}
+			}
+
+			var element, _, kind, err = Dereference(temp.Interface())
+
+			if nil != err {
+				return err
+			} else if kind == reflect.Ptr {
+				continue
+			}
+
+			var identifier map[string]interface{}
+
+			if identifier, err = v.IdentifyResource(element, typ, included); nil != err {
+				return err
+			}
+
+			var id, _ = v.Identifier(element)
+
+			ids = append(ids, id)
+			identifiers = append(identifiers, identifier)
+		}
+
+		relationship[v.ReservedStrings.Data] = identifiers
+	}
+
+	var links map[string]interface{}
+
+	if links, err = v.relationshipLinks(p, attr, href, typ, ids); nil != err {
+		return err
+	} else if 0 < len(links) {
+		relationship[v.ReservedStrings.Links] = links
+	}
+
+	return nil
+}
+
+// relationshipLinks returns the links of the relationship named
+// `a` of the struct value `p` to resources of the formatted type
+// `t` with the identifiers `ids`, formatting the href `h` as its
+// `related` link if present, or nil if it has none. The URL path
+// segment `relationships` is fixed by JSON API, whatever the
+// reserved string of the member.
+func (v *V1) relationshipLinks(p reflect.Value, a, h, t string, ids []interface{}) (map[string]interface{}, error) {
+	var (
+		links         map[string]interface{}
+		self, ok, err = v.SelfLink(p)
+	)
+
+	if nil != err {
+		return nil, err
+	} else if ok {
+		var segment = url.PathEscape(a)
+
+		links = map[string]interface{}{
+			v.ReservedStrings.Self:    self + "/relationships/" + segment,
+			v.ReservedStrings.Related: self + "/" + segment,
+		}
+	}
+
+	if 0 < len(h) {
+		var (
+			parent = v.Metadata(p.Type()).Name
+			id, _  = v.Identifier(p)
+		)
+
+		if nil == links {
+			links = make(map[string]interface{})
+		}
+
+		links[v.ReservedStrings.Related] = v.FormatOwnerHref(h, parent, id, t, ids)
+	}
+
+	return links, nil
+}
+
+// IdentifyResource returns the JSON API resource identifier
+// object for the struct value `r` of the formatted type `t`,
+// sideloading it under the JSON API reserved string `included`
+// if it is a compound document and `i` is true.
+func (v *V1) IdentifyResource(r reflect.Value, t string, i bool) (map[string]interface{}, error) {
+	var id, ok = v.Identifier(r)
+
+	if !ok {
+		return nil, MissingIdentifierError{r, v.IdentifierStrategy}
+	}
+
+	if i && v.IsCompoundDocument(r) {
+		if err := v.IncludeDocument(r); nil != err {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		v.ReservedStrings.Type: t,
+		v.ReservedStrings.ID:   fmt.Sprint(id),
+	}, nil
+}
+
+// IncludeDocument serializes the struct value `r` and appends
+// it to the resources sideloaded under the JSON API reserved
+// string `included`. Resources already included are resolved
+// according to the ConflictPolicy, resources present as
+// primary data or along the Path, or past the MaxDepth,
+// are never included.
+func (v *V1) IncludeDocument(r reflect.Value) error {
+	if v.IsVisiting(r) || v.IsMaxDepth() {
+		return nil
+	}
+
+	var key, err = v.DocumentKey(r)
+
+	if nil != err {
+		return err
+	}
+
+	var existing, found = v.LinkedDocuments[key]
+
+	if found && (nil == existing || v.ConflictPolicy == ConflictFirstWins) {
+		return nil
+	} else if !found {
+		existing = make(map[string]interface{})
+		v.LinkedDocuments[key] = existing
+	}
+
+	var resource map[string]interface{}
+
+	if resource, err = v.SerializeResource(r); nil != err {
+		return err
+	} else if found {
+		return v.ResolveConflict(key, existing, resource)
+	}
+
+	mergeDocument(existing, resource)
+	v.Included = append(v.Included, existing)
+
+	return nil
+}
+
+// resourceMembers returns the formatted JSON API reserved strings
+// naming the members of resource objects in the order they are
+// encoded in, reporting false if two are formatted alike.
+func (v *V1) resourceMembers() ([6]string, bool) {
+	var keys = [...]string{
+		v.ReservedStrings.Type,
+		v.ReservedStrings.ID,
+		v.ReservedStrings.Attributes,
+		v.ReservedStrings.Relationships,
+		v.ReservedStrings.Links,
+		v.ReservedStrings.Meta,
+	}
+
+	for i := 1; i < len(keys); i++ {
+		for j := i; 0 < j && keys[j] < keys[j-1]; j-- {
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+
+	for i := 1; i < len(keys); i++ {
+		if keys[i] == keys[i-1] {
+			return keys, false
+		}
+	}
+
+	return keys, true
+}
+
+// streamResource writes the resource object of the struct value
+// `r` to the Encoder `e` as SerializeResource serializes it,
+// member by member. Resources of types whose attribute names are
+// not unique or whose relationships are not declared in the order
+// of their names are built by SerializeResource first, as are all
+// resources if two reserved strings naming their members are
+// formatted alike. Stream only writes resources once `links`
+// is known to follow `data`, the order relationships need.
+func (v *V1) streamResource(e *Encoder, r reflect.Value) error {
+	var (
+		metadata = v.Metadata(r.Type())
+		keys, ok = v.resourceMembers()
+		resource map[string]interface{}
+		err      error
+	)
+
+	if !ok || !metadata.streamable {
+		if resource, err = v.SerializeResource(r); nil != err {
+			return err
+		}
+
+		return e.WriteValue(resource)
+	}
+
+	if err = v.Err(); nil != err {
+		return err
+	}
+
+	var id, identified = metadata.identifier(r)
+
+	if !identified {
+		return MissingIdentifierError{r, v.IdentifierStrategy}
+	} else if err = v.EnterDocument(r); nil != err {
+		return err
+	}
+
+	defer v.LeaveDocument()
+
+	var (
+		fieldset map[string]struct{}
+		sparse   bool
+		written  = 0
+	)
+
+	if fieldset, sparse, err = v.Fieldset(r.Type()); nil != err {
+		return err
+	} else if err = e.WriteRaw("{"); nil != err {
+		return err
+	}
+
+	for i := 0; i < len(keys) && nil == err; i++ {
+		switch keys[i] {
+		case v.ReservedStrings.Type:
+			if err = e.writeMember(&written, keys[i]); nil == err {
+				err = e.writeString(metadata.Name)
+			}
+		case v.ReservedStrings.ID:
+			if err = e.writeMember(&written, keys[i]); nil == err {
+				err = e.writeIdentifier(id)
+			}
+		case v.ReservedStrings.Attributes:
+			err = v.streamAttributes(e, &written, r, metadata, fieldset, sparse)
+		case v.ReservedStrings.Relationships:
+			err = v.streamRelationships(e, &written, r, metadata, fieldset, sparse)
+		case v.ReservedStrings.Links:
+			err = v.streamSelfLink(e, &written, r)
+		case v.ReservedStrings.Meta:
+			var meta map[string]interface{}
+
+			if meta, err = v.ResourceMeta(r); nil == err && 0 < len(meta) {
+				if err = e.writeMember(&written, keys[i]); nil == err {
+					err = e.WriteValue(meta)
+				}
+			}
+		}
+	}
+
+	if nil != err {
+		return err
+	}
+
+	return e.WriteRaw("}")
+}
+
+// streamAttributes writes the attributes of the struct value `r`
+// in order of their names, as the member `attributes` of the
+// resource object with `n` members written, unless it has none.
+func (v *V1) streamAttributes(e *Encoder, n *int, r reflect.Value, m *TypeMetadata, s map[string]struct{}, sparse bool) error {
+	var written = 0
+
+	for i := 0; i < len(m.sorted); i++ {
+		var (
+			field                = &m.Fields[m.sorted[i]]
+			val, _, _, role, err = v.classifyField(r, field, s, sparse)
+		)
+
+		if nil != err {
+			return err
+		} else if fieldOmitted == role || fieldLinked == role {
+			continue
+		}
+
+		if 0 == written {
+			if err = e.writeMember(n, v.ReservedStrings.Attributes); nil != err {
+				return err
+			} else if err = e.WriteRaw("{"); nil != err {
+				return err
+			}
+		}
+
+		if err = e.writeMember(&written, field.Name); nil != err {
+			return err
+		} else if err = v.streamAttributeValue(e, val, role); nil != err {
+			return err
+		}
+	}
+
+	if 0 < written {
+		return e.WriteRaw("}")
+	}
+
+	return nil
+}
+
+// streamRelationships writes the relationships of the struct value
+// `r` in order of their names, as the member `relationships` of the
+// resource object with `n` members written, unless it has none.
+func (v *V1) streamRelationships(e *Encoder, n *int, r reflect.Value, m *TypeMetadata, s map[string]struct{}, sparse bool) error {
+	var written = 0
+
+	for i := 0; i < len(m.sorted); i++ {
+		var field = &m.Fields[m.sorted[i]]
+
+		if !field.Link {
+			continue
+		}
+
+		var val, ftyp, kind, role, err = v.classifyField(r, field, s, sparse)
+
+		if nil != err {
+			return err
+		} else if fieldLinked != role {
+			continue
+		}
+
+		if 0 == written {
+			if err = e.writeMember(n, v.ReservedStrings.Relationships); nil != err {
+				return err
+			} else if err = e.WriteRaw("{"); nil != err {
+				return err
+			}
+		}
+
+		if err = e.writeMember(&written, field.Name); nil != err {
+			return err
+		} else if err = v.streamRelationship(e, r, val, ftyp, kind, field); nil != err {
+			return err
+		}
+	}
+
+	if 0 < written {
+		return e.WriteRaw("}")
+	}
+
+	return nil
+}
+
+// streamRelationship writes the relationship object of the field
+// `f` of the struct value `p` to the Encoder `e`, as
+// RelateStructField serializes it, for its dereferenced value `r`
+// of the reflect.Type `t` and reflect.Kind `k`.
+func (v *V1) streamRelationship(e *Encoder, p, r reflect.Value, t reflect.Type, k reflect.Kind, f *FieldMetadata) error {
+	var (
+		typ               = v.Metadata(t).Name
+		ids               []interface{}
+		include, included = v.IncludePaths.Relationship(f.Name)
+		parent            = v.IncludePaths
+		err               error
+	)
+
+	v.IncludePaths = include
+	defer func() { v.IncludePaths = parent }()
+
+	if 0 < len(f.Href) {
+		ids = make([]interface{}, 0, 0)
+	}
+
+	if err = e.WriteRaw("{"); nil != err {
+		return err
+	} else if err = e.WriteKey(v.ReservedStrings.Data); nil != err {
+		return err
+	}
+
+	switch k {
+	case reflect.Ptr:
+		err = e.WriteRaw("null")
+	case reflect.Struct:
+		if err = v.streamIdentifier(e, r, typ, included); nil == err && nil != ids {
+			var id, _ = v.Identifier(r)
+
+			ids = append(ids, id)
+		}
+	default:
+		ids, err = v.streamIdentifiers(e, r, typ, included, ids)
+	}
+
+	if nil != err {
+		return err
+	}
+
+	var links map[string]interface{}
+
+	if links, err = v.relationshipLinks(p, f.Name, f.Href, typ, ids); nil != err {
+		return err
+	} else if 0 < len(links) {
+		if err = e.WriteRaw(","); nil != err {
+			return err
+		} else if err = e.WriteKey(v.ReservedStrings.Links); nil != err {
+			return err
+		} else if err = e.WriteValue(links); nil != err {
+			return err
+		}
+	}
+
+	return e.WriteRaw("}")
+}
+
+// streamIdentifiers writes the resource identifier objects of the
+// struct values of the slice or array value `r` of the formatted
+// type `t` to the Encoder `e` as an array, skipping nil pointers.
+// Their identifiers are appended to `ids` unless it is nil.
+func (v *V1) streamIdentifiers(e *Encoder, r reflect.Value, t string, i bool, ids []interface{}) ([]interface{}, error) {
+	var written = 0
+
+	if err := e.WriteRaw("["); nil != err {
+		return nil, err
+	}
+
+	for j := 0; j < r.Len(); j++ {
+		var temp = r.Index(j)
+
+		if !temp.CanInterface() {
+			return nil, UninterfaceableValueError{temp}
+		}
+
+		var element, _, kind, err = Dereference(temp.Interface())
+
+		if nil != err {
+			return nil, err
+		} else if kind == reflect.Ptr {
+			continue
+		} else if 0 < written {
+			if err = e.WriteRaw(","); nil != err {
+				return nil, err
+			}
+		}
+
+		written++
+
+		if err = v.streamIdentifier(e, element, t, i); nil != err {
+			return nil, err
+		} else if nil != ids {
+			var id, _ = v.Identifier(element)
+
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, e.WriteRaw("]")
+}
+
+// streamIdentifier writes the resource identifier object of the
+// struct value `r` of the formatted type `t` to the Encoder `e`,
+// sideloading it as IdentifyResource does.
+func (v *V1) streamIdentifier(e *Encoder, r reflect.Value, t string, i bool) error {
+	var id, ok = v.Identifier(r)
+
+	if !ok {
+		return MissingIdentifierError{r, v.IdentifierStrategy}
+	}
+
+	if i && v.IsCompoundDocument(r) {
+		if err := v.IncludeDocument(r); nil != err {
+			return err
+		}
+	}
+
+	var (
+		members = [2]string{v.ReservedStrings.ID, v.ReservedStrings.Type}
+		written = 0
+		err     error
+	)
+
+	if members[1] < members[0] {
+		members[0], members[1] = members[1], members[0]
+	}
+
+	if err = e.WriteRaw("{"); nil != err {
+		return err
+	}
+
+	for j := 0; j < len(members) && nil == err; j++ {
+		if err = e.writeMember(&written, members[j]); nil != err {
+			return err
+		} else if members[j] == v.ReservedStrings.ID {
+			err = e.writeIdentifier(id)
+		} else {
+			err = e.writeString(t)
+		}
+	}
+
+	if nil != err {
+		return err
+	}
+
+	return e.WriteRaw("}")
+}
+
+// streamSelfLink writes the links of the struct value `r`, as the
+// member `links` of the resource object with `n` members written,
+// if it has a self link.
+func (v *V1) streamSelfLink(e *Encoder, n *int, r reflect.Value) error {
+	var self, ok, err = v.SelfLink(r)
+
+	if nil != err || !ok {
+		return err
+	} else if err = e.writeMember(n, v.ReservedStrings.Links); nil != err {
+		return err
+	} else if err = e.WriteRaw("{"); nil != err {
+		return err
+	} else if err = e.WriteKey(v.ReservedStrings.Self); nil != err {
+		return err
+	} else if err = e.writeString(self); nil != err {
+		return err
+	}
+
+	return e.WriteRaw("}")
+}
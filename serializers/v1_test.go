@@ -0,0 +1,511 @@
+package serializers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+	"github.com/stretchr/testify/assert"
+)
+
+func NewV1() *serializers.V1 {
+	var v1 = &serializers.V1{
+		Base: serializers.Base{
+			TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
+				return strings.ToLower(s) + "s"
+			}),
+			AttributeNameFormatter: serializers.NamingFormatterFunc(strings.ToLower),
+			HrefFormatter: serializers.HrefFormatterFunc(func(h, o, c string, i []interface{}) string {
+				return h
+			}),
+		},
+	}
+
+	v1.ReservedStrings.ID = "id"
+	v1.ReservedStrings.Type = "type"
+	v1.ReservedStrings.Data = "data"
+	v1.ReservedStrings.Links = "links"
+	v1.ReservedStrings.Attributes = "attributes"
+	v1.ReservedStrings.Relationships = "relationships"
+	v1.ReservedStrings.Included = "included"
+	v1.ReservedStrings.Related = "related"
+	v1.ReservedStrings.Meta = "meta"
+
+	return v1
+}
+
+func TestV1Accept(t *testing.T) {
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	type Comment struct {
+		ID     int
+		Body   string
+		Author Person `tranq_link:"true"`
+	}
+
+	type Post struct {
+		ID       int
+		Title    string
+		Author   Person    `tranq_link:"true" tranq_href:"/posts/1/author"`
+		Editor   *Person   `tranq_link:"true"`
+		Comments []Comment `tranq_link:"true"`
+	}
+
+	var (
+		jon  = Person{1, "Jon"}
+		jane = Person{2, "Jane"}
+		post = Post{1, "Lorem", jon, nil, []Comment{
+			Comment{1, "First", jane},
+			Comment{2, "Second", jon},
+		}}
+		result, err = NewV1().Accept(post)
+	)
+
+	assert.Nil(t, err, "received unexpected error from V1's Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"data": {
+			"type": "posts",
+			"id": "1",
+			"attributes": {"title": "Lorem"},
+			"relationships": {
+				"author": {"data": {"type": "persons", "id": "1"}, "links": {"related": "/posts/1/author"}},
+				"editor": {"data": null},
+				"comments": {"data": [{"type": "comments", "id": "1"}, {"type": "comments", "id": "2"}]}
+			}
+		},
+		"included": [
+			{"type": "persons", "id": "1", "attributes": {"name": "Jon"}},
+			{"type": "persons", "id": "2", "attributes": {"name": "Jane"}},
+			{
+				"type": "comments",
+				"id": "1",
+				"attributes": {"body": "First"},
+				"relationships": {"author": {"data": {"type": "persons", "id": "2"}}}
+			},
+			{
+				"type": "comments",
+				"id": "2",
+				"attributes": {"body": "Second"},
+				"relationships": {"author": {"data": {"type": "persons", "id": "1"}}}
+			}
+		]
+	}`, string(encoded), "failed to produce JSON API 1.0 document")
+}
+
+func TestV1AcceptCollection(t *testing.T) {
+	type Post struct {
+		ID    int
+		Title string
+	}
+
+	var result, err = NewV1().Accept([]*Post{&Post{1, "First"}, nil, &Post{2, "Second"}})
+
+	assert.Nil(t, err, "received unexpected error from V1's Accept")
+	assert.Len(t, result["data"], 2, "failed to serialize collection of resources")
+	assert.NotContains(t, result, "included", "failed to omit empty included member")
+}
+
+func TestV1AcceptNil(t *testing.T) {
+	type Post struct {
+		ID int
+	}
+
+	var (
+		post        *Post
+		result, err = NewV1().Accept(post)
+	)
+
+	assert.Nil(t, err, "received unexpected error from V1's Accept")
+	assert.Contains(t, result, "data", "failed to establish data member")
+	assert.Nil(t, result["data"], "failed to serialize nil resource as nil data")
+}
+
+func TestV1AcceptUnsupported(t *testing.T) {
+	var _, err = NewV1().Accept(1)
+
+	assert.IsType(t, serializers.UnsupportedKindError{}, err, "error was not type of serializers.UnsupportedKindError")
+}
+
+func TestV1SerializeResourceMissingIdentifier(t *testing.T) {
+	type Tag struct {
+		Name string
+	}
+
+	var (
+		serializer = NewV1()
+		_, err     = serializer.SerializeResource(reflect.ValueOf(Tag{"tag"}))
+	)
+
+	assert.IsType(t, serializers.MissingIdentifierError{}, err, "error was not type of serializers.MissingIdentifierError")
+
+	serializer.IdentifierStrategy = serializers.TagIdentifier(serializers.Tranq)
+	_, err = serializer.SerializeResource(reflect.ValueOf(Tag{"tag"}))
+
+	assert.Contains(t, err.Error(), "field tagged `tranq:\"id\"`", "failed to name the IdentifierStrategy's tag in error message")
+}
+
+func TestV1SerializeResourceTags(t *testing.T) {
+	type Person struct {
+		ID int
+	}
+
+	type Post struct {
+		ID       int
+		Title    string  `tranq:"heading"`
+		Subtitle string  `tranq:",omitempty"`
+		Secret   string  `tranq:"-"`
+		Author   Person  `tranq:"writer" tranq_link:"true"`
+		Editor   *Person `tranq:",omitempty" tranq_link:"true"`
+	}
+
+	var resource, err = NewV1().SerializeResource(reflect.ValueOf(Post{1, "Lorem", "", "secret", Person{2}, nil}))
+
+	assert.Nil(t, err, "received unexpected error from SerializeResource with tagged fields")
+	assert.Equal(t, map[string]interface{}{"heading": "Lorem"}, resource["attributes"], "failed to apply `tranq` struct tags to attributes")
+	assert.Contains(t, resource["relationships"], "writer", "failed to rename relationship with `tranq` struct tag")
+	assert.NotContains(t, resource["relationships"], "editor", "failed to omit empty relationship")
+}
+
+func TestV1AcceptNilTimePointer(t *testing.T) {
+	type Person struct {
+		ID        int
+		DeletedAt *time.Time
+	}
+
+	type Post struct {
+		ID        int
+		Title     string
+		DeletedAt *time.Time `tranq:",omitempty"`
+		Author    Person     `tranq_link:"true"`
+	}
+
+	var result, err = NewV1().Accept(Post{ID: 1, Title: "Lorem", Author: Person{ID: 2}})
+
+	assert.Nil(t, err, "received unexpected error from Accept with nil *time.Time fields")
+	assert.NotContains(t, result["data"].(map[string]interface{})["attributes"], "deletedat", "failed to omit nil *time.Time attribute")
+	assert.NotContains(t, result, "included", "failed to link identifier only resource with nil *time.Time field by reference")
+}
+
+func TestV1AcceptNilInterfaces(t *testing.T) {
+	type Post struct {
+		ID    int
+		Extra interface{}
+		Err   error
+	}
+
+	var result, err = NewV1().Accept(Post{ID: 1})
+
+	assert.Nil(t, err, "received unexpected error from Accept with nil interfaces")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{"data": {"type": "posts", "id": "1", "attributes": {"extra": null, "err": null}}}`, string(encoded), "failed to serialize nil interfaces as null")
+}
+
+func TestV1AcceptIdentifierStrategy(t *testing.T) {
+	var serializer = NewV1()
+
+	serializer.IdentifierStrategy = serializers.MethodIdentifier{}
+
+	var result, err = serializer.Accept(Method{"jon"})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var data = result["data"].(map[string]interface{})
+
+	assert.Equal(t, "jon", data["id"], "failed to serialize identifier returned by Identifier method")
+	assert.Equal(t, map[string]interface{}{"name": "jon"}, data["attributes"], "failed to serialize attributes")
+}
+
+func TestV1AcceptCyclicLinks(t *testing.T) {
+	var (
+		jon        = &Friend{ID: 1, Name: "Jon"}
+		jane       = &Friend{ID: 2, Name: "Jane", Friend: jon}
+		serializer = NewV1()
+	)
+
+	jon.Friend = jane
+
+	var result, err = serializer.Accept(jon)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Len(t, result["included"], 1, "failed to include each resource once")
+}
+
+func TestV1AcceptMaxDepth(t *testing.T) {
+	var (
+		root       = &Friend{ID: 1}
+		last       = root
+		serializer = NewV1()
+	)
+
+	serializer.MaxDepth = 2
+
+	for i := 2; i <= 4; i++ {
+		last.Friend = &Friend{ID: i, Name: fmt.Sprint(i)}
+		last = last.Friend
+	}
+
+	var result, err = serializer.Accept(root)
+
+	if !assert.Nil(t, err, "received unexpected error from Accept of path deeper than MaxDepth") {
+		return
+	}
+
+	var included = result["included"].([]interface{})
+
+	assert.Len(t, included, 1, "failed to stop including resources at MaxDepth")
+	assert.Equal(t, "2", included[0].(map[string]interface{})["id"], "failed to include resources up to MaxDepth")
+	assert.Equal(t, "3", included[0].(map[string]interface{})["relationships"].(map[string]interface{})["friend"].(map[string]interface{})["data"].(map[string]interface{})["id"], "failed to relate resource past MaxDepth by reference")
+}
+
+func TestV1AcceptConflictPolicy(t *testing.T) {
+	var serializer = NewV1()
+
+	serializer.ConflictPolicy = serializers.ConflictLastWins
+
+	var result, err = serializer.Accept(Team{1, Member{1, "Jon", "", nil}, []Member{Member{1, "Jon", "jon@example.com", nil}}})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var included = result["included"].([]interface{})
+
+	assert.Len(t, included, 1, "failed to deduplicate included resources by type and identifier")
+	assert.Equal(t, "jon@example.com", included[0].(map[string]interface{})["attributes"].(map[string]interface{})["email"], "failed to keep last value")
+}
+
+func TestV1AcceptInclude(t *testing.T) {
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	type Comment struct {
+		ID     int
+		Body   string
+		Author Person `tranq_link:"true"`
+	}
+
+	type Post struct {
+		ID       int
+		Author   Person    `tranq_link:"true"`
+		Comments []Comment `tranq_link:"true"`
+	}
+
+	var (
+		serializer = NewV1()
+		post       = Post{1, Person{1, "Jon"}, []Comment{Comment{1, "First", Person{2, "Jane"}}}}
+	)
+
+	var result, err = serializer.Accept(post, serializers.Include("comments"))
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result["included"])
+
+	assert.JSONEq(t, `[
+		{"type": "comments", "id": "1", "attributes": {"body": "First"}, "relationships": {
+			"author": {"data": {"type": "persons", "id": "2"}}
+		}}
+	]`, string(encoded), "failed to sideload only included relationships")
+
+	result, err = serializer.Accept(post, serializers.Include("comments.author"))
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Len(t, result["included"], 2, "failed to sideload nested included relationships")
+
+	result, err = serializer.Accept(post)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Len(t, result["included"], 3, "failed to sideload every compound document without include specification")
+}
+
+func TestV1AcceptFields(t *testing.T) {
+	type Post struct {
+		ID     int
+		Title  string
+		Body   string
+		Author Friend `tranq_link:"true"`
+	}
+
+	var result, err = NewV1().Accept(Post{1, "Lorem", "Ipsum", Friend{ID: 2, Name: "Jon"}},
+		serializers.Fields("posts", "title"),
+		serializers.Fields("friends", "name"),
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"data": {"type": "posts", "id": "1", "attributes": {"title": "Lorem"}}
+	}`, string(encoded), "failed to apply sparse fieldsets")
+
+	result, err = NewV1().Accept(Post{1, "Lorem", "Ipsum", Friend{ID: 2, Name: "Jon"}},
+		serializers.Fields("posts", "author"),
+		serializers.Fields("friends", "name"),
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"data": {"type": "posts", "id": "1", "relationships": {
+			"author": {"data": {"type": "friends", "id": "2"}}
+		}},
+		"included": [{"type": "friends", "id": "2", "attributes": {"name": "Jon"}}]
+	}`, string(encoded), "failed to apply sparse fieldsets to included resources")
+
+	_, err = NewV1().Accept(Post{ID: 1}, serializers.Fields("posts", "summary"), serializers.StrictFields())
+	assert.Equal(t, serializers.UnknownFieldError{"posts", "summary"}, err, "failed to return serializers.UnknownFieldError")
+}
+
+func TestV1AcceptMeta(t *testing.T) {
+	var result, err = NewV1().Accept([]Revision{{ID: 1, Title: "Lorem", Version: 2}},
+		serializers.Meta(map[string]interface{}{"total": 1}),
+	)
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"data": [{"type": "revisions", "id": "1", "attributes": {"title": "Lorem"}, "meta": {"version": 2, "stale": true}}],
+		"meta": {"total": 1}
+	}`, string(encoded), "failed to serialize meta information")
+}
+
+func TestV1AcceptSelfLinks(t *testing.T) {
+	type Writer struct {
+		ID   int
+		Name string
+	}
+
+	type Story struct {
+		ID     int
+		Title  string
+		Author Writer  `tranq_link:"true"`
+		Editor *Writer `tranq_link:"true" tranq_href:"/editors/1"`
+	}
+
+	var serializer = NewV1()
+
+	serializer.ReservedStrings.Self = "self"
+	serializer.SelfTemplates = map[string]string{"storys": "/stories", "writers": "/writers"}
+
+	var result, err = serializer.Accept(Story{1, "Lorem", Writer{2, "Jon"}, nil})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"data": {
+			"type": "storys",
+			"id": "1",
+			"attributes": {"title": "Lorem"},
+			"relationships": {
+				"author": {
+					"data": {"type": "writers", "id": "2"},
+					"links": {"self": "/stories/1/relationships/author", "related": "/stories/1/author"}
+				},
+				"editor": {
+					"data": null,
+					"links": {"self": "/stories/1/relationships/editor", "related": "/editors/1"}
+				}
+			},
+			"links": {"self": "/stories/1"}
+		},
+		"included": [{"type": "writers", "id": "2", "attributes": {"name": "Jon"}, "links": {"self": "/writers/2"}}]
+	}`, string(encoded), "failed to serialize self links")
+}
+
+func TestV1AcceptRelationshipSelfLinks(t *testing.T) {
+	type Writer struct {
+		ID int
+	}
+
+	type Story struct {
+		ID     int
+		Author Writer `tranq_link:"true"`
+	}
+
+	var serializer = NewV1()
+
+	serializer.AttributeNameFormatter = serializers.NamingFormatterFunc(func(s string) string {
+		return "lead " + strings.ToLower(s)
+	})
+	serializer.ReservedStrings.Self = "self"
+	serializer.ReservedStrings.Relationships = "rels"
+	serializer.SelfTemplates = map[string]string{"storys": "/stories"}
+
+	var result, err = serializer.Accept(Story{1, Writer{2}})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+	assert.Equal(t, map[string]interface{}{
+		"self":    "/stories/1/relationships/lead%20author",
+		"related": "/stories/1/lead%20author",
+	}, result["data"].(map[string]interface{})["rels"].(map[string]interface{})["lead author"].(map[string]interface{})["links"], "failed to link relationship by escaped name under fixed path segment")
+}
+
+func TestV1AcceptTemplateHref(t *testing.T) {
+	type Writer struct {
+		ID int
+	}
+
+	type Story struct {
+		ID     int
+		Author Writer `tranq_link:"true" tranq_href:"/{owner}/{owner.id}/{child}{/ids*}"`
+	}
+
+	var serializer = NewV1()
+
+	serializer.HrefFormatter = serializers.TemplateHrefFormatter{}
+
+	var result, err = serializer.Accept(Story{1, Writer{2}})
+
+	assert.Nil(t, err, "received unexpected error from Accept")
+
+	var encoded, _ = json.Marshal(result)
+
+	assert.JSONEq(t, `{
+		"data": {"type": "storys", "id": "1", "relationships": {
+			"author": {"data": {"type": "writers", "id": "2"}, "links": {"related": "/storys/1/writers/2"}}
+		}}
+	}`, string(encoded), "failed to expand href template")
+}
+
+func TestV1AcceptContext(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.WithValue(context.Background(), TContextKey{}, "key"))
+		calls       = 0
+		result, err = NewV1().AcceptContext(ctx, Draft{ID: 1})
+	)
+
+	defer cancel()
+
+	assert.Nil(t, err, "received unexpected error from AcceptContext")
+	assert.Equal(t, map[string]interface{}{"key": "key"}, result["data"].(map[string]interface{})["meta"], "failed to pass context to ContextMetaProvider")
+
+	_, err = NewV1().AcceptContext(ctx, drafts(16, cancel, &calls))
+
+	assert.Equal(t, context.Canceled, err, "failed to return error of done context")
+	assert.Equal(t, 1, calls, "failed to stop serializing resources once context was done")
+
+	_, err = NewV1().AcceptContext(ctx, []Draft{})
+	assert.Equal(t, context.Canceled, err, "failed to return error of context done before serialization")
+}
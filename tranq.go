@@ -1,24 +1,131 @@
 package tranq
 
-import "github.com/chuckpreslar/tranq/configurators"
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/deserializers"
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+// UnsupportedDeserializationError occurs when the
+// embedded configurators.Configurator does not
+// implement the configurators.DeserializingConfigurator
+// interface.
+type UnsupportedDeserializationError struct {
+	Configurator configurators.Configurator
+}
+
+// Error implements the `error` interface for the
+// UnsupportedDeserializationError type.
+func (u UnsupportedDeserializationError) Error() string {
+	return fmt.Sprintf("configurator `%T` does not support deserialization", u.Configurator)
+}
 
 // Tranq stores an instnace of the configurators.Configurator
 // interface for creating and configuring serialization.Serializer
-// instances.
+// instances. A Tranq created by New shares a single Serializer
+// and Deserializer between calls, and may be used by multiple
+// goroutines concurrently if they allow it, as those of the
+// serializers and deserializers packages do.
 type Tranq struct {
 	configurators.Configurator
+	// serializer is the serializers.Serializer
+	// created by New from the Configurator.
+	serializer serializers.Serializer
+	// deserializer is the deserializers.Deserializer
+	// created by New from the Configurator, if it
+	// supports deserialization.
+	deserializer deserializers.Deserializer
 }
 
-// Serialize uses the embedded configurators.Configurator
-// instance to create a new serialization.Serializer
-// instance and start serialization.
-func (t *Tranq) Serialize(i interface{}) (map[string]interface{}, error) {
-	return t.NewSerializer().Accept(i)
+// Serializer returns the serializers.Serializer shared by calls
+// to Serialize and Encode, creating a new one with the embedded
+// configurators.Configurator if the Tranq was not created by New.
+func (t *Tranq) Serializer() serializers.Serializer {
+	if nil == t.serializer {
+		return t.NewSerializer()
+	}
+
+	return t.serializer
 }
 
-// New returns a new instance of the Tranq type.
+// Serialize uses the shared serialization.Serializer
+// instance to start serialization, passing along any
+// serializers.Option provided.
+func (t *Tranq) Serialize(i interface{}, options ...serializers.Option) (map[string]interface{}, error) {
+	return t.Serializer().Accept(i, options...)
+}
+
+// SerializeContext serializes `i` as Serialize does, passing
+// `ctx` to the serialization.Serializer's AcceptContext method.
+// Once `ctx` is done, serialization stops with its error.
+func (t *Tranq) SerializeContext(ctx context.Context, i interface{}, options ...serializers.Option) (map[string]interface{}, error) {
+	return t.Serializer().AcceptContext(ctx, i, options...)
+}
+
+// Encode uses the shared serialization.Serializer instance,
+// writing the JSON encoded document for `i` to `w` with a
+// serializers.Encoder.
+func (t *Tranq) Encode(w io.Writer, i interface{}, options ...serializers.Option) error {
+	return serializers.NewEncoder(w, t.Serializer()).Encode(i, options...)
+}
+
+// EncodeContext writes the JSON encoded document for `i` to `w`
+// as Encode does, passing `ctx` to the serializers.Encoder's
+// EncodeContext method.
+func (t *Tranq) EncodeContext(ctx context.Context, w io.Writer, i interface{}, options ...serializers.Option) error {
+	return serializers.NewEncoder(w, t.Serializer()).EncodeContext(ctx, i, options...)
+}
+
+// Deserializer returns the deserializers.Deserializer shared by
+// calls to Deserialize, creating a new one with the embedded
+// configurators.Configurator if the Tranq was not created by New.
+// If the Configurator does not implement the
+// configurators.DeserializingConfigurator interface, an
+// UnsupportedDeserializationError is returned.
+func (t *Tranq) Deserializer() (deserializers.Deserializer, error) {
+	if nil != t.deserializer {
+		return t.deserializer, nil
+	}
+
+	var configurator, ok = t.Configurator.(configurators.DeserializingConfigurator)
+
+	if !ok {
+		return nil, UnsupportedDeserializationError{t.Configurator}
+	}
+
+	return configurator.NewDeserializer(), nil
+}
+
+// Deserialize uses the shared deserializers.Deserializer
+// instance to populate the go object pointed to by `i`
+// with the JSON API document `m`.
+func (t *Tranq) Deserialize(m map[string]interface{}, i interface{}) error {
+	var deserializer, err = t.Deserializer()
+
+	if nil != err {
+		return err
+	}
+
+	return deserializer.Accept(m, i)
+}
+
+// New returns a new instance of the Tranq type, creating its
+// Serializer, and its Deserializer if supported, with the
+// Configurator `c` once.
 func New(c configurators.Configurator) *Tranq {
 	var t = new(Tranq)
 	t.Configurator = c
+	t.serializer = c.NewSerializer()
+
+	if configurator, ok := c.(configurators.DeserializingConfigurator); ok {
+		t.deserializer = configurator.NewDeserializer()
+	}
+
 	return t
 }
@@ -1,12 +1,16 @@
 package tranq_test
 
 import (
+	"bytes"
+	"context"
+	"sync"
 	"testing"
 )
 
 import (
 	"github.com/chuckpreslar/tranq"
 	"github.com/chuckpreslar/tranq/configurators"
+	"github.com/chuckpreslar/tranq/deserializers"
 	"github.com/chuckpreslar/tranq/serializers"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,7 +20,7 @@ func TestNew(t *testing.T) {
 		typ    = "type"
 		attr   = "attribute"
 		href   = "href"
-		config = &configurators.Base{
+		config = configurators.NewBase(configurators.Settings{
 			TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
 				return typ
 			}),
@@ -26,7 +30,7 @@ func TestNew(t *testing.T) {
 			HrefFormatter: serializers.HrefFormatterFunc(func(h, o, c string, i []interface{}) string {
 				return href
 			}),
-		}
+		})
 		serializer = tranq.New(config)
 	)
 
@@ -44,7 +48,7 @@ func TestSerialize(t *testing.T) {
 		typ    = "type"
 		attr   = "attribute"
 		href   = "href"
-		config = &configurators.Base{
+		config = configurators.NewBase(configurators.Settings{
 			TypeNameFormatter: serializers.NamingFormatterFunc(func(s string) string {
 				return typ
 			}),
@@ -54,7 +58,7 @@ func TestSerialize(t *testing.T) {
 			HrefFormatter: serializers.HrefFormatterFunc(func(h, o, c string, i []interface{}) string {
 				return href
 			}),
-		}
+		})
 		serializer  = tranq.New(config)
 		result, err = serializer.Serialize(TStruct{test})
 	)
@@ -65,3 +69,203 @@ func TestSerialize(t *testing.T) {
 	result = result[typ].(map[string]interface{})
 	assert.Equal(t, test, result[attr], "failed to estabish attribute returned from AttributeNameFormatter provided by configurators.Base")
 }
+
+func TestDeserialize(t *testing.T) {
+	type TStruct struct {
+		Test string
+	}
+
+	var (
+		test       = "test"
+		serializer = tranq.New(&configurators.Base{})
+		result     TStruct
+		err        = serializer.Deserialize(map[string]interface{}{"TStruct": map[string]interface{}{"Test": test}}, &result)
+	)
+
+	assert.Nil(t, err, "tranq.Tranq's `Deserialize` method returned an unexpected error, %s", err)
+	assert.Equal(t, test, result.Test, "failed to deserialize attribute into value provided to tranq.Tranq's `Deserialize` method")
+}
+
+type TConfigurator struct{}
+
+func (t TConfigurator) NewSerializer() serializers.Serializer {
+	return &serializers.Base{}
+}
+
+func TestDeserializeUnsupported(t *testing.T) {
+	var (
+		serializer = tranq.New(TConfigurator{})
+		result     struct{}
+		err        = serializer.Deserialize(map[string]interface{}{}, &result)
+	)
+
+	assert.IsType(t, tranq.UnsupportedDeserializationError{}, err, "failed to return tranq.UnsupportedDeserializationError")
+}
+
+func TestSerializeOptions(t *testing.T) {
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	type Post struct {
+		ID     int
+		Author Person `tranq_link:"true"`
+	}
+
+	var (
+		config      = &configurators.V1{}
+		result, err = tranq.New(config).Serialize(Post{1, Person{1, "Jon"}}, serializers.Include(""))
+	)
+
+	assert.Nil(t, err, "received unexpected error from Serialize")
+	assert.NotContains(t, result, configurators.Included, "failed to pass serializers.Option to Accept")
+}
+
+func TestSerializeContext(t *testing.T) {
+	type Post struct {
+		ID    int
+		Title string
+	}
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		serializer  = tranq.New(&configurators.V1{})
+		result, err = serializer.SerializeContext(ctx, Post{1, "Lorem"})
+		buffer      bytes.Buffer
+	)
+
+	assert.Nil(t, err, "received unexpected error from SerializeContext")
+	assert.Contains(t, result, configurators.Data, "failed to serialize document")
+
+	cancel()
+
+	_, err = serializer.SerializeContext(ctx, Post{1, "Lorem"})
+	assert.Equal(t, context.Canceled, err, "failed to return error of done context")
+
+	err = serializer.EncodeContext(ctx, &buffer, []Post{{1, "Lorem"}})
+	assert.Equal(t, context.Canceled, err, "failed to return error of done context")
+	assert.Equal(t, 0, buffer.Len(), "wrote document after context was done")
+}
+
+type TCountingConfigurator struct {
+	configurators.Base
+	count int
+}
+
+func (t *TCountingConfigurator) NewSerializer() serializers.Serializer {
+	t.count++
+	return t.Base.NewSerializer()
+}
+
+func TestSerializeSharesSerializer(t *testing.T) {
+	var (
+		config     = new(TCountingConfigurator)
+		serializer = tranq.New(config)
+	)
+
+	for i := 0; i < 3; i++ {
+		serializer.Serialize(struct{ ID int }{i})
+	}
+
+	assert.Equal(t, 1, config.count, "failed to share serializers.Serializer between calls to Serialize")
+	assert.Equal(t, serializer.Serializer(), serializer.Serializer(), "failed to return shared serializers.Serializer")
+
+	(&tranq.Tranq{Configurator: config}).Serialize(struct{ ID int }{1})
+
+	assert.Equal(t, 2, config.count, "failed to create serializers.Serializer for Tranq not created by New")
+}
+
+func TestSerializeConcurrent(t *testing.T) {
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	type Post struct {
+		ID       int
+		Author   Person   `tranq_link:"true"`
+		Comments []Person `tranq_link:"true"`
+	}
+
+	for _, config := range []configurators.Configurator{&configurators.Base{}, &configurators.V1{}} {
+		var (
+			serializer = tranq.New(config)
+			group      sync.WaitGroup
+		)
+
+		for i := 0; i < 8; i++ {
+			group.Add(1)
+
+			go func(i int) {
+				defer group.Done()
+
+				for j := 0; j < 16; j++ {
+					var (
+						post        = Post{i, Person{j, "Jon"}, []Person{{j + 1, "Jane"}}}
+						result, err = serializer.Serialize(post, serializers.Include("Author"))
+						expected, _ = config.NewSerializer().Accept(post, serializers.Include("Author"))
+						buffer      bytes.Buffer
+					)
+
+					assert.Nil(t, err, "received unexpected error from concurrent Serialize")
+					assert.Equal(t, expected, result, "failed to serialize concurrently")
+					assert.Nil(t, serializer.Encode(&buffer, post), "received unexpected error from concurrent Encode")
+				}
+			}(i)
+		}
+
+		group.Wait()
+	}
+}
+
+type TCountingDeserializingConfigurator struct {
+	configurators.V1
+	count int
+}
+
+func (t *TCountingDeserializingConfigurator) NewDeserializer() deserializers.Deserializer {
+	t.count++
+	return t.V1.NewDeserializer()
+}
+
+func TestDeserializeConcurrent(t *testing.T) {
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	type Post struct {
+		ID     int
+		Author Person `tranq_link:"true"`
+	}
+
+	var (
+		config     = new(TCountingDeserializingConfigurator)
+		serializer = tranq.New(config)
+		group      sync.WaitGroup
+	)
+
+	for i := 0; i < 8; i++ {
+		group.Add(1)
+
+		go func(i int) {
+			defer group.Done()
+
+			for j := 0; j < 16; j++ {
+				var (
+					post        = Post{i, Person{j, "Jon"}}
+					document, _ = serializer.Serialize(post)
+					result      Post
+				)
+
+				assert.Nil(t, serializer.Deserialize(document, &result), "received unexpected error from concurrent Deserialize")
+				assert.Equal(t, post, result, "failed to deserialize concurrently")
+			}
+		}(i)
+	}
+
+	group.Wait()
+
+	assert.Equal(t, 1, config.count, "failed to share deserializers.Deserializer between calls to Deserialize")
+}
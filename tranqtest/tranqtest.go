@@ -0,0 +1,78 @@
+// Package tranqtest provides utilities for testing serializers,
+// such as those generated by cmd/tranqgen, against the
+// reflective serializers of the serializers package.
+package tranqtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+import (
+	"github.com/chuckpreslar/tranq/serializers"
+)
+
+// Compare serializes `i` with both the Serializer `s` and the
+// Serializer `r`, passing the same Options, returning an error
+// describing the first difference between their documents
+// encoded by json.Marshal, or their errors.
+func Compare(s, r serializers.Serializer, i interface{}, options ...serializers.Option) error {
+	return CompareContext(context.Background(), s, r, i, options...)
+}
+
+// CompareContext compares the documents serialized by the
+// AcceptContext methods of `s` and `r` with the context.Context
+// `ctx`, as Compare does.
+func CompareContext(ctx context.Context, s, r serializers.Serializer, i interface{}, options ...serializers.Option) error {
+	var (
+		document, err     = s.AcceptContext(ctx, i, options...)
+		reference, refErr = r.AcceptContext(ctx, i, options...)
+	)
+
+	if nil != err || nil != refErr {
+		if nil == err || nil == refErr || err.Error() != refErr.Error() {
+			return fmt.Errorf("errors differ:\n\tgot:  %v\n\twant: %v", err, refErr)
+		}
+
+		return nil
+	}
+
+	var encoded, expected []byte
+
+	if encoded, err = json.Marshal(document); nil != err {
+		return err
+	} else if expected, err = json.Marshal(reference); nil != err {
+		return err
+	} else if !bytes.Equal(encoded, expected) {
+		return fmt.Errorf("documents differ:\n\tgot:  %s\n\twant: %s", encoded, expected)
+	}
+
+	return nil
+}
+
+// AssertEquivalent reports a test failure through `t` if the
+// Serializer `s` does not serialize `i` as the Serializer `r`
+// does, i.e. a generated serializer and the serializers.Base
+// it wraps. It returns true if their output matches.
+func AssertEquivalent(t testing.TB, s, r serializers.Serializer, i interface{}, options ...serializers.Option) bool {
+	t.Helper()
+
+	return AssertEquivalentContext(t, context.Background(), s, r, i, options...)
+}
+
+// AssertEquivalentContext reports a test failure through `t` as
+// AssertEquivalent does, serializing `i` with the context.Context
+// `ctx`.
+func AssertEquivalentContext(t testing.TB, ctx context.Context, s, r serializers.Serializer, i interface{}, options ...serializers.Option) bool {
+	t.Helper()
+
+	if err := CompareContext(ctx, s, r, i, options...); nil != err {
+		t.Errorf("serializing %T: %s", i, err)
+		return false
+	}
+
+	return true
+}